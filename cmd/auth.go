@@ -0,0 +1,283 @@
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alan-gomes1/jira-reporter/internal/config"
+	"github.com/alan-gomes1/jira-reporter/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Gerencia as credenciais da API do Jira",
+}
+
+var authLoginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Verifica e armazena o token da API do Jira",
+	Long: `Pede o token da API do Jira, verifica-o na API myself com o jira.url e
+ o jira.email configurados e o armazena no chaveiro do sistema (keyring) ou
+ no arquivo criptografado de credenciais (file), em vez de mantê-lo em texto
+ puro na configuração.
+ Com jira.auth bearer, o token pedido é o personal access token do Jira
+ Data Center. Com jira.auth oauth, o login abre a autorização do app OAuth
+ no navegador, recebe o código em oauth.redirect_uri e armazena o refresh
+ token.
+ Para usar o token armazenado, defina credentials.provider na configuração.`,
+	Run: runAuthLogin,
+}
+
+// runAuthLogin verifica o token informado e o armazena no provedor de
+// credenciais.
+func runAuthLogin(cmd *cobra.Command, args []string) {
+	providerName, _ := cmd.Flags().GetString("provider")
+	tokenStdin, _ := cmd.Flags().GetBool("token-stdin")
+
+	// Carrega as configurações sem exigir o token, que ainda será armazenado
+	opts, err := loadOptionsFromFlags(cmd)
+	if err != nil {
+		log.Fatalf("Erro ao carregar configurações: %v", err)
+	}
+	opts.Partial = true
+	cfg, err := config.Load(opts)
+	if err != nil {
+		log.Fatalf("Erro ao carregar configurações: %v", err)
+	}
+	if cfg.JiraURL == "" {
+		log.Fatalf(
+			"Erro: configure jira.url antes do login " +
+				"(ex: --set jira.url=https://empresa.atlassian.net)",
+		)
+	}
+	if cfg.JiraAuth == config.AuthBasic && cfg.JiraEmail == "" {
+		log.Fatalf("Erro: configure jira.email antes do login")
+	}
+
+	if providerName == "" {
+		providerName = cfg.TokenProvider
+	}
+	if providerName == "" {
+		providerName = config.CredentialKeyring
+	}
+	provider, err := config.NewCredentialProvider(
+		providerName, cfg, promptPassphrase,
+	)
+	if err != nil {
+		log.Fatalf("Erro ao inicializar o provedor de credenciais: %v", err)
+	}
+
+	var token, name string
+	if cfg.JiraAuth == config.AuthOAuth {
+		token, name, err = loginOAuth(cfg)
+		if err != nil {
+			log.Fatalf("Erro na autorização OAuth: %v", err)
+		}
+	} else {
+		if token, err = readToken(cfg.JiraAuth, tokenStdin); err != nil {
+			log.Fatalf("Erro ao ler o token: %v", err)
+		}
+
+		// Verifica o token antes de armazená-lo
+		if name, err = authenticate(cfg, token); err != nil {
+			log.Fatalf("Erro ao verificar o token no Jira: %v", err)
+		}
+	}
+	fmt.Printf("Autenticado no Jira como %s\n", name)
+
+	account := cfg.CredentialAccount()
+	if err := provider.Store(account, token); err != nil {
+		log.Fatalf("Erro ao armazenar o token: %v", err)
+	}
+	fmt.Printf("Token de %s armazenado (%s)\n", account, provider.Name())
+
+	if cfg.TokenProvider != providerName {
+		fmt.Printf(
+			"Para usá-lo, defina credentials.provider: %s no arquivo de "+
+				"configuração (ou %s=%s)\n",
+			providerName, config.EnvName("credentials.provider"), providerName,
+		)
+	}
+}
+
+// readToken lê o token da entrada padrão (--token-stdin) ou do terminal,
+// sem exibi-lo.
+func readToken(auth string, fromStdin bool) (string, error) {
+	if !fromStdin {
+		if auth == config.AuthBearer {
+			return promptSecret("Personal access token do Jira: ")
+		}
+		return promptSecret("Token da API do Jira: ")
+	}
+
+	content, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", err
+	}
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", fmt.Errorf("nenhum token recebido na entrada padrão")
+	}
+	return token, nil
+}
+
+// authenticate verifica o token na API myself com o site e o e-mail da
+// configuração, retornando o nome do usuário autenticado.
+func authenticate(cfg *config.Config, token string) (string, error) {
+	candidate := *cfg
+	candidate.JiraToken = token
+
+	repo, err := repository.NewJiraRepository(&candidate, nil, os.Stdout)
+	if err != nil {
+		return "", err
+	}
+	return repo.Authenticate()
+}
+
+// loginOAuth conduz a autorização OAuth: exibe o endereço de autorização,
+// recebe o código em oauth.redirect_uri, troca-o pelos tokens e verifica o
+// token de acesso. Retorna o refresh token e o nome do usuário autenticado.
+func loginOAuth(cfg *config.Config) (string, string, error) {
+	flow, err := repository.NewOAuthFlow(cfg, nil)
+	if err != nil {
+		return "", "", err
+	}
+
+	state, err := randomState()
+	if err != nil {
+		return "", "", err
+	}
+	address, err := flow.AuthorizationURL(state)
+	if err != nil {
+		return "", "", err
+	}
+
+	fmt.Printf("Abra o endereço abaixo no navegador e autorize o acesso:\n\n%s\n\n", address)
+	code, err := waitOAuthCode(cfg.OAuthRedirectURI, state)
+	if err != nil {
+		return "", "", err
+	}
+
+	accessToken, refreshToken, err := flow.Exchange(code)
+	if err != nil {
+		return "", "", err
+	}
+	cloudID, err := flow.CloudID(accessToken)
+	if err != nil {
+		return "", "", err
+	}
+
+	// Verifica o token de acesso diretamente no gateway do site
+	candidate := *cfg
+	candidate.JiraAuth = config.AuthBearer
+	candidate.JiraURL = repository.OAuthSite(cloudID)
+	name, err := authenticate(&candidate, accessToken)
+	if err != nil {
+		return "", "", fmt.Errorf("erro ao verificar o token no Jira: %w", err)
+	}
+	return refreshToken, name, nil
+}
+
+// oauthTimeout é o tempo de espera pela autorização no navegador.
+const oauthTimeout = 5 * time.Minute
+
+// waitOAuthCode atende o endereço de redirecionamento local até receber o
+// código de autorização com o state esperado.
+func waitOAuthCode(redirectURI, state string) (string, error) {
+	redirect, err := url.Parse(redirectURI)
+	if err != nil || redirect.Host == "" {
+		return "", fmt.Errorf("oauth.redirect_uri inválido: %s", redirectURI)
+	}
+
+	listener, err := net.Listen("tcp", redirect.Host)
+	if err != nil {
+		return "", fmt.Errorf("erro ao aguardar o redirecionamento em %s: %w", redirect.Host, err)
+	}
+
+	type result struct {
+		code string
+		err  error
+	}
+	results := make(chan result, 1)
+	path := redirect.Path
+	if path == "" {
+		path = "/"
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		var res result
+		switch {
+		case query.Get("error") != "":
+			res.err = fmt.Errorf(
+				"autorização negada: %s %s",
+				query.Get("error"), query.Get("error_description"),
+			)
+		case query.Get("state") != state:
+			res.err = errors.New("state inválido no redirecionamento")
+		case query.Get("code") == "":
+			res.err = errors.New("redirecionamento sem código de autorização")
+		default:
+			res.code = query.Get("code")
+		}
+
+		if res.err != nil {
+			http.Error(w, res.err.Error(), http.StatusBadRequest)
+		} else {
+			fmt.Fprintln(w, "Autorização concluída. Você já pode fechar esta janela.")
+		}
+		select {
+		case results <- res:
+		default:
+		}
+	})
+
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go server.Serve(listener)
+	defer server.Shutdown(context.Background())
+
+	select {
+	case res := <-results:
+		return res.code, res.err
+	case <-time.After(oauthTimeout):
+		return "", fmt.Errorf("tempo esgotado aguardando a autorização (%s)", oauthTimeout)
+	}
+}
+
+// randomState gera o state que protege o redirecionamento contra CSRF.
+func randomState() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("erro ao gerar o state: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func init() {
+	rootCmd.AddCommand(authCmd)
+	authCmd.AddCommand(authLoginCmd)
+
+	authLoginCmd.Flags().String(
+		"provider", "",
+		"Onde armazenar o token (keyring ou file). "+
+			"Padrão: credentials.provider ou keyring",
+	)
+	authLoginCmd.Flags().Bool(
+		"token-stdin", false,
+		"Lê o token da entrada padrão em vez de pedi-lo no terminal "+
+			"(ex: em scripts). Ignorado com jira.auth oauth",
+	)
+}
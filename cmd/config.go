@@ -0,0 +1,427 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/alan-gomes1/jira-reporter/internal/config"
+	"github.com/alan-gomes1/jira-reporter/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspeciona e edita a configuração",
+}
+
+var configInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Cria a configuração respondendo a algumas perguntas",
+	Long: `Pergunta os dados do Jira, da autenticação e da empresa e os grava no
+ arquivo de configuração (--config ou ~/.config/jira-reporter/config.yaml),
+ na seção defaults ou no contexto informado. Os valores já configurados são
+ sugeridos como resposta padrão.`,
+	Args: cobra.NoArgs,
+	Run:  runConfigInit,
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Exibe os valores efetivos da configuração e as suas origens",
+	Long: `Exibe o valor de cada chave depois de combinadas as camadas (padrão,
+ variáveis, arquivo e --set) e a origem de cada um. Os valores secretos,
+ como jira.token, são mascarados.`,
+	Args: cobra.NoArgs,
+	Run:  runConfigShow,
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Valida a configuração e testa a conexão com o Jira",
+	Long: `Verifica a configuração, reportando todos os problemas de uma vez, e
+ testa a autenticação na API myself do Jira.`,
+	Args: cobra.NoArgs,
+	Run:  runConfigValidate,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <chave> <valor>",
+	Short: "Grava uma chave no arquivo de configuração",
+	Long: `Grava o valor da chave no arquivo de configuração, preservando os
+ demais valores e os comentários. Sem --context, grava na seção defaults.
+ Exemplo: jira-reporter config set hourly_rate 150 --context cliente-a`,
+	Args: cobra.ExactArgs(2),
+	Run:  runConfigSet,
+}
+
+// runConfigShow exibe os valores efetivos da configuração.
+func runConfigShow(cmd *cobra.Command, args []string) {
+	opts, err := loadOptionsFromFlags(cmd)
+	if err != nil {
+		log.Fatalf("Erro ao carregar configurações: %v", err)
+	}
+	// Exibe a configuração mesmo incompleta, sem pedir senhas
+	opts.Partial = true
+	cfg, err := config.Load(opts)
+	if err != nil {
+		log.Fatalf("Erro ao carregar configurações: %v", err)
+	}
+
+	fmt.Printf("Arquivo:  %s\n", orNone(cfg.File))
+	fmt.Printf("Contexto: %s\n\n", orNone(cfg.Context))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CHAVE\tVALOR\tORIGEM")
+	for _, entry := range cfg.Entries() {
+		source := entry.Source
+		if source == "" {
+			source = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", entry.Key, entry.Value, source)
+	}
+	w.Flush()
+}
+
+// runConfigValidate valida a configuração e testa a conexão com o Jira.
+func runConfigValidate(cmd *cobra.Command, args []string) {
+	offline, _ := cmd.Flags().GetBool("offline")
+
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		fmt.Println("Configuração inválida:")
+		for _, line := range strings.Split(err.Error(), "\n") {
+			fmt.Printf("  - %s\n", line)
+		}
+		os.Exit(1)
+	}
+	fmt.Printf(
+		"Configuração válida (arquivo: %s, contexto: %s)\n",
+		orNone(cfg.File), orNone(cfg.Context),
+	)
+	if offline {
+		return
+	}
+
+	fmt.Printf("Testando a conexão com %s...\n", cfg.JiraURL)
+	repo, err := repository.NewJiraRepository(cfg, nil, os.Stdout)
+	if err != nil {
+		log.Fatalf("Erro ao conectar ao Jira: %v", err)
+	}
+	name, err := repo.Authenticate()
+	if err != nil {
+		log.Fatalf("Erro ao conectar ao Jira: %v", err)
+	}
+	fmt.Printf("Conectado ao Jira como %s\n", name)
+}
+
+// runConfigSet grava uma chave no arquivo de configuração.
+func runConfigSet(cmd *cobra.Command, args []string) {
+	file, _ := cmd.Flags().GetString("config")
+	context, _ := cmd.Flags().GetString("context")
+	key, value := strings.TrimSpace(args[0]), args[1]
+
+	path, err := config.SetFileValues(
+		file, context, config.KeyValue{Key: key, Value: value},
+	)
+	if err != nil {
+		log.Fatalf("Erro ao gravar a configuração: %v", err)
+	}
+
+	section := "defaults"
+	if context != "" {
+		section = "contexto " + context
+	}
+	fmt.Printf("%s gravado em %s (%s)\n", key, path, section)
+
+	if key == "jira.token" {
+		fmt.Println(
+			"Aviso: o token fica em texto puro no arquivo; prefira " +
+				"'jira-reporter auth login' com credentials.provider",
+		)
+	}
+}
+
+// wizard faz as perguntas do config init na entrada padrão.
+type wizard struct {
+	in *bufio.Reader
+}
+
+// ask pergunta um valor, sugerindo o valor padrão. Uma resposta vazia
+// mantém o padrão.
+func (w *wizard) ask(label, defaultValue string) (string, error) {
+	if defaultValue != "" {
+		fmt.Printf("%s [%s]: ", label, defaultValue)
+	} else {
+		fmt.Printf("%s: ", label)
+	}
+
+	line, err := w.in.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		return "", fmt.Errorf("resposta não recebida para %q: %w", label, err)
+	}
+	if answer := strings.TrimSpace(line); answer != "" {
+		return answer, nil
+	}
+	return defaultValue, nil
+}
+
+// required pergunta um valor até que ele seja informado.
+func (w *wizard) required(label, defaultValue string) (string, error) {
+	for {
+		answer, err := w.ask(label, defaultValue)
+		if err != nil || answer != "" {
+			return answer, err
+		}
+		fmt.Println("  Valor obrigatório.")
+	}
+}
+
+// choose pergunta uma das opções até que uma opção válida seja informada.
+func (w *wizard) choose(label string, options []string, defaultValue string) (string, error) {
+	label = fmt.Sprintf("%s (%s)", label, strings.Join(options, ", "))
+	for {
+		answer, err := w.ask(label, defaultValue)
+		if err != nil {
+			return "", err
+		}
+		for _, option := range options {
+			if strings.EqualFold(answer, option) {
+				return option, nil
+			}
+		}
+		fmt.Printf("  Opção inválida: %s\n", answer)
+	}
+}
+
+// confirm faz uma pergunta de sim ou não.
+func (w *wizard) confirm(label string, defaultYes bool) (bool, error) {
+	defaultValue := "n"
+	if defaultYes {
+		defaultValue = "s"
+	}
+	answer, err := w.choose(label, []string{"s", "n"}, defaultValue)
+	return answer == "s", err
+}
+
+// noCredentialProvider é a opção do config init que grava o token no
+// próprio arquivo de configuração.
+const noCredentialProvider = "nenhum"
+
+// runConfigInit cria a configuração a partir das respostas do usuário.
+func runConfigInit(cmd *cobra.Command, args []string) {
+	file, _ := cmd.Flags().GetString("config")
+	context, _ := cmd.Flags().GetString("context")
+
+	// Os valores atuais, quando existem, são sugeridos como resposta
+	current := &config.Config{
+		JiraDeployment: config.DeploymentCloud,
+		JiraAuth:       config.AuthBasic,
+	}
+	if opts, err := loadOptionsFromFlags(cmd); err == nil {
+		opts.Partial = true
+		if cfg, err := config.Load(opts); err == nil {
+			current = cfg
+		}
+	}
+
+	w := &wizard{in: bufio.NewReader(os.Stdin)}
+	values, setCurrent, err := askConfig(w, current, &context)
+	if err != nil {
+		log.Fatalf("Erro no assistente de configuração: %v", err)
+	}
+
+	path, err := config.SetFileValues(file, context, values...)
+	if err != nil {
+		log.Fatalf("Erro ao gravar a configuração: %v", err)
+	}
+	if setCurrent {
+		if _, err := config.SetCurrentContext(path, context); err != nil {
+			log.Fatalf("Erro ao gravar a configuração: %v", err)
+		}
+	}
+	fmt.Printf("\nConfiguração gravada em %s\n", path)
+
+	provider := valueOf(values, "credentials.provider")
+	if provider == config.CredentialKeyring || provider == config.CredentialFile {
+		fmt.Println("Próximo passo: 'jira-reporter auth login' para armazenar o token")
+	}
+	fmt.Println("Para testar a conexão: 'jira-reporter config validate'")
+}
+
+// askConfig faz as perguntas do config init e retorna os valores a gravar
+// e se o contexto deve passar a ser o current_context.
+func askConfig(
+	w *wizard, current *config.Config, context *string,
+) ([]config.KeyValue, bool, error) {
+	var (
+		values []config.KeyValue
+		err    error
+	)
+	set := func(key, value string) {
+		if value != "" {
+			values = append(values, config.KeyValue{Key: key, Value: value})
+		}
+	}
+
+	setCurrent := false
+	if *context == "" {
+		*context, err = w.ask(
+			"Contexto (ex: nome do cliente; vazio grava em defaults)", "",
+		)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	if *context != "" {
+		if setCurrent, err = w.confirm(
+			fmt.Sprintf("Usar %s como contexto atual", *context), true,
+		); err != nil {
+			return nil, false, err
+		}
+	}
+
+	fmt.Println("\nJira")
+	url, err := w.required("URL do Jira (ex: https://empresa.atlassian.net)", current.JiraURL)
+	if err != nil {
+		return nil, false, err
+	}
+	set("jira.url", url)
+
+	deployment, err := w.choose("Instalação", config.Deployments(), current.JiraDeployment)
+	if err != nil {
+		return nil, false, err
+	}
+	set("jira.deployment", deployment)
+
+	modes := []string{config.AuthBasic, config.AuthBearer}
+	if deployment == config.DeploymentCloud {
+		modes = config.AuthModes()
+	}
+	auth, err := w.choose("Autenticação", modes, defaultOption(modes, current.JiraAuth))
+	if err != nil {
+		return nil, false, err
+	}
+	set("jira.auth", auth)
+
+	switch auth {
+	case config.AuthBasic:
+		email, err := w.required("E-mail (ou usuário) do Jira", current.JiraEmail)
+		if err != nil {
+			return nil, false, err
+		}
+		set("jira.email", email)
+	case config.AuthOAuth:
+		clientID, err := w.required("Client ID do app OAuth", current.OAuthClientID)
+		if err != nil {
+			return nil, false, err
+		}
+		set("oauth.client_id", clientID)
+		clientSecret, err := w.required("Secret do app OAuth", current.OAuthClientSecret)
+		if err != nil {
+			return nil, false, err
+		}
+		set("oauth.client_secret", clientSecret)
+	}
+
+	providers := append(config.CredentialProviders(), noCredentialProvider)
+	if auth == config.AuthOAuth {
+		// O refresh token renovado precisa ser regravado
+		providers = []string{config.CredentialKeyring, config.CredentialFile}
+	}
+	provider, err := w.choose(
+		"Onde guardar o token", providers,
+		defaultOption(providers, current.TokenProvider),
+	)
+	if err != nil {
+		return nil, false, err
+	}
+	switch provider {
+	case noCredentialProvider:
+		token, err := promptSecret("Token (gravado em texto puro no arquivo): ")
+		if err != nil {
+			return nil, false, err
+		}
+		set("jira.token", token)
+	case config.CredentialCommand:
+		set("credentials.provider", provider)
+		command, err := w.required("Comando que imprime o token", current.TokenCommand)
+		if err != nil {
+			return nil, false, err
+		}
+		set("credentials.command", command)
+	default:
+		set("credentials.provider", provider)
+	}
+
+	fmt.Println("\nEmpresa")
+	for _, field := range []struct {
+		key, label, current string
+	}{
+		{"company_name", "Nome da empresa", current.CompanyName},
+		{"cnpj", "CNPJ", current.CNPJ},
+		{"user_name", "Seu nome", current.Username},
+	} {
+		answer, err := w.required(field.label, field.current)
+		if err != nil {
+			return nil, false, err
+		}
+		set(field.key, answer)
+	}
+
+	timezone, err := w.ask(
+		"Fuso horário (ex: America/Sao_Paulo; vazio usa o do perfil no Jira)",
+		current.TimeZone,
+	)
+	if err != nil {
+		return nil, false, err
+	}
+	set("timezone", timezone)
+
+	return values, setCurrent, nil
+}
+
+// defaultOption retorna o valor atual quando ele é uma das opções, ou a
+// primeira opção.
+func defaultOption(options []string, value string) string {
+	for _, option := range options {
+		if option == value {
+			return value
+		}
+	}
+	return options[0]
+}
+
+// valueOf retorna o valor da chave entre os valores a gravar.
+func valueOf(values []config.KeyValue, key string) string {
+	for _, kv := range values {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return ""
+}
+
+// orNone retorna o texto, ou "nenhum" quando vazio.
+func orNone(text string) string {
+	if text == "" {
+		return "nenhum"
+	}
+	return text
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(
+		configInitCmd, configShowCmd, configValidateCmd, configSetCmd,
+	)
+
+	configValidateCmd.Flags().Bool(
+		"offline", false, "Apenas valida a configuração, sem testar a conexão",
+	)
+}
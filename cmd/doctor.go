@@ -0,0 +1,380 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/alan-gomes1/jira-reporter/internal/config"
+	"github.com/alan-gomes1/jira-reporter/internal/repository"
+	"github.com/alan-gomes1/jira-reporter/internal/view"
+	"github.com/spf13/cobra"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnostica a configuração e o ambiente",
+	Long: `Verifica o que é necessário para gerar os relatórios: a configuração,
+ o template HTML, a permissão de escrita no diretório dos relatórios, o
+ LibreOffice (motor DOCX libreoffice), o acesso, a autenticação e as
+ permissões no Jira e os campos de QA do perfil de consulta.
+ Exibe o resultado de cada verificação com uma dica de correção e termina
+ com erro quando alguma verificação falha.`,
+	Args: cobra.NoArgs,
+	Run:  runDoctor,
+}
+
+// Resultados das verificações do doctor.
+const (
+	checkOK      = "OK"
+	checkWarning = "AVISO"
+	checkFailed  = "FALHA"
+	checkSkipped = "IGNORADO"
+)
+
+// doctorTimeout limita o tempo da verificação de acesso ao Jira.
+const doctorTimeout = 10 * time.Second
+
+// browsePermission é a permissão necessária para buscar as issues.
+const browsePermission = "BROWSE_PROJECTS"
+
+// checkResult é o resultado de uma verificação do doctor.
+type checkResult struct {
+	name   string
+	status string
+	detail string
+	hint   string // Como corrigir (exibida quando a verificação não passa)
+}
+
+// doctor executa as verificações, guardando os resultados.
+type doctor struct {
+	results []checkResult
+}
+
+// add registra o resultado de uma verificação.
+func (d *doctor) add(name, status, detail, hint string) {
+	d.results = append(d.results, checkResult{
+		name: name, status: status, detail: detail, hint: hint,
+	})
+}
+
+// failed verifica se alguma verificação falhou.
+func (d *doctor) failed() bool {
+	for _, result := range d.results {
+		if result.status == checkFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// print exibe a tabela dos resultados seguida das dicas de correção.
+func (d *doctor) print() {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERIFICAÇÃO\tRESULTADO\tDETALHE")
+	for _, result := range d.results {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", result.name, result.status, result.detail)
+	}
+	w.Flush()
+
+	var hints []string
+	for _, result := range d.results {
+		if result.hint != "" && result.status != checkOK {
+			hints = append(hints, fmt.Sprintf("  - %s: %s", result.name, result.hint))
+		}
+	}
+	if len(hints) > 0 {
+		fmt.Printf("\nComo corrigir:\n%s\n", strings.Join(hints, "\n"))
+	}
+}
+
+// runDoctor executa as verificações do ambiente.
+func runDoctor(cmd *cobra.Command, args []string) {
+	reportPath, _ := cmd.Flags().GetString("path")
+	templateFlag, _ := cmd.Flags().GetString("template")
+	docxEngine, _ := cmd.Flags().GetString("docx-engine")
+	profileName, _ := cmd.Flags().GetString("profile")
+
+	d := &doctor{}
+	cfg := d.checkConfig(cmd)
+	d.checkTemplate(templateFlag, cfg)
+	d.checkReportDir(reportPath)
+	d.checkDOCXConverter(docxEngine)
+	d.checkJira(cfg, profileName)
+
+	d.print()
+	if d.failed() {
+		os.Exit(1)
+	}
+}
+
+// checkConfig carrega e valida a configuração. Retorna a configuração
+// carregada, mesmo incompleta, para as demais verificações, ou nil quando
+// ela não pode ser lida.
+func (d *doctor) checkConfig(cmd *cobra.Command) *config.Config {
+	const name = "Configuração"
+	const hint = "execute 'jira-reporter config init' ou confira os valores " +
+		"com 'jira-reporter config show'"
+
+	opts, err := loadOptionsFromFlags(cmd)
+	if err != nil {
+		d.add(name, checkFailed, err.Error(), hint)
+		return nil
+	}
+	opts.Partial = true
+	cfg, err := config.Load(opts)
+	if err != nil {
+		d.add(name, checkFailed, firstLine(err), hint)
+		return nil
+	}
+
+	if err := cfg.Check(opts.Passphrase); err != nil {
+		problems := strings.Split(err.Error(), "\n")
+		detail := problems[0]
+		if len(problems) > 1 {
+			detail = fmt.Sprintf("%s (e mais %d problemas)", detail, len(problems)-1)
+		}
+		d.add(name, checkFailed, detail, hint+"; 'jira-reporter config validate' lista todos os problemas")
+		return cfg
+	}
+	d.add(name, checkOK, fmt.Sprintf(
+		"arquivo: %s, contexto: %s", orNone(cfg.File), orNone(cfg.Context),
+	), "")
+	return cfg
+}
+
+// checkTemplate localiza o template HTML e o executa com dados de exemplo.
+func (d *doctor) checkTemplate(flagPath string, cfg *config.Config) {
+	const name = "Template HTML"
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+
+	templatePath, err := resolveTemplatePath(flagPath, cfg, false, io.Discard)
+	if err != nil {
+		d.add(name, checkFailed, err.Error(),
+			"corrija o caminho ou remova template_path para usar o template embutido")
+		return
+	}
+	if err := view.ValidateTemplates(templatePath); err != nil {
+		d.add(name, checkFailed, firstLine(err), fmt.Sprintf(
+			"corrija o template %s ou remova-o para usar o embutido", templatePath,
+		))
+		return
+	}
+
+	if templatePath == "" {
+		templatePath = "embutido"
+	}
+	d.add(name, checkOK, templatePath, "")
+}
+
+// checkReportDir verifica a permissão de escrita no diretório dos
+// relatórios ou, quando ele ainda não existe, no diretório onde ele será
+// criado.
+func (d *doctor) checkReportDir(path string) {
+	const name = "Diretório dos relatórios"
+	if path == "" {
+		path = "reports"
+	}
+
+	dir := path
+	for {
+		info, err := os.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				d.add(name, checkFailed, dir+" não é um diretório",
+					"use --path com um diretório")
+				return
+			}
+			break
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			d.add(name, checkFailed, err.Error(), "use --path com um diretório acessível")
+			return
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	probe, err := os.CreateTemp(dir, ".jira-reporter-doctor-*")
+	if err != nil {
+		d.add(name, checkFailed, fmt.Sprintf("sem permissão de escrita em %s", dir),
+			"ajuste as permissões do diretório ou use --path com um diretório gravável")
+		return
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+
+	detail := path
+	if dir != path {
+		detail = fmt.Sprintf("%s (será criado em %s)", path, dir)
+	}
+	d.add(name, checkOK, detail, "")
+}
+
+// checkDOCXConverter verifica o LibreOffice, obrigatório apenas com o motor
+// DOCX libreoffice.
+func (d *doctor) checkDOCXConverter(engine string) {
+	const name = "Conversor DOCX"
+	const hint = "instale o LibreOffice (ex: sudo apt-get install " +
+		"libreoffice-writer) ou use --docx-engine native"
+
+	path, err := view.FindLibreOffice()
+	switch {
+	case err == nil:
+		d.add(name, checkOK, "LibreOffice em "+path, "")
+	case engine == docxEngineLibreOffice:
+		d.add(name, checkFailed, "LibreOffice não encontrado", hint)
+	default:
+		d.add(name, checkWarning,
+			"LibreOffice não encontrado; o motor native será usado", hint)
+	}
+}
+
+// checkJira verifica o acesso ao Jira, a autenticação, a permissão de
+// navegar nos projetos e os campos de QA do perfil de consulta.
+func (d *doctor) checkJira(cfg *config.Config, profileName string) {
+	checks := []string{"Acesso ao Jira", "Autenticação", "Permissões", "Campos de QA"}
+	skip := func(from int, reason string) {
+		for _, name := range checks[from:] {
+			d.add(name, checkSkipped, reason, "")
+		}
+	}
+
+	if cfg == nil || cfg.JiraURL == "" {
+		skip(0, "jira.url não configurado")
+		return
+	}
+
+	// O /status responde sem autenticação no Cloud e no Server/Data Center
+	client := &http.Client{Timeout: doctorTimeout}
+	response, err := client.Get(strings.TrimRight(cfg.JiraURL, "/") + "/status")
+	if err != nil {
+		d.add(checks[0], checkFailed, firstLine(err),
+			"confira jira.url, a conexão com a rede e o proxy (HTTPS_PROXY)")
+		skip(1, "Jira inacessível")
+		return
+	}
+	response.Body.Close()
+
+	detail := fmt.Sprintf("%s (%s)", cfg.JiraURL, response.Status)
+	switch {
+	case response.StatusCode == http.StatusServiceUnavailable:
+		// Manutenção ou indisponibilidade temporária: a URL está correta
+		d.add(checks[0], checkWarning, detail,
+			"o Jira está temporariamente indisponível; tente novamente mais tarde")
+		skip(1, "Jira indisponível")
+		return
+	case response.StatusCode < 200 || response.StatusCode > 299:
+		d.add(checks[0], checkFailed, detail,
+			"confira jira.url: o endereço não respondeu como um Jira")
+		skip(1, "Jira respondeu com erro")
+		return
+	default:
+		d.add(checks[0], checkOK, detail, "")
+	}
+
+	repo, err := repository.NewJiraRepository(cfg, nil, io.Discard)
+	var user string
+	if err == nil {
+		user, err = repo.Authenticate()
+	}
+	if err != nil {
+		d.add(checks[1], checkFailed, firstLine(err), authHint(cfg))
+		skip(2, "sem autenticação")
+		return
+	}
+	d.add(checks[1], checkOK, fmt.Sprintf("%s (%s)", user, cfg.JiraAuth), "")
+
+	missing, err := repo.MissingPermissions([]string{browsePermission})
+	switch {
+	case err != nil:
+		d.add(checks[2], checkWarning, firstLine(err), "")
+	case len(missing) > 0:
+		d.add(checks[2], checkFailed, "sem a permissão "+strings.Join(missing, ", "),
+			"peça ao administrador do Jira a permissão Browse Projects nos projetos do relatório")
+	default:
+		d.add(checks[2], checkOK, browsePermission, "")
+	}
+
+	d.checkQAFields(repo, cfg, profileName)
+}
+
+// checkQAFields verifica se os campos de QA do perfil existem no Jira.
+func (d *doctor) checkQAFields(
+	repo repository.JiraRepository, cfg *config.Config, profileName string,
+) {
+	const name = "Campos de QA"
+
+	profile, err := cfg.Profile(profileName)
+	if err != nil {
+		d.add(name, checkFailed, err.Error(), "use --profile com um perfil configurado")
+		return
+	}
+	if len(profile.QAFields) == 0 {
+		d.add(name, checkSkipped, fmt.Sprintf("perfil %s sem qa_fields", profile.Name), "")
+		return
+	}
+
+	missing, err := repo.MissingFields(profile.QAFields)
+	switch {
+	case err != nil:
+		d.add(name, checkWarning, firstLine(err), "")
+	case len(missing) > 0:
+		d.add(name, checkFailed, "não encontrados: "+strings.Join(missing, ", "), fmt.Sprintf(
+			"ajuste profiles.%s.qa_fields com o nome do campo no Jira "+
+				"(a opção -q usa esses campos na JQL)", profile.Name,
+		))
+	default:
+		d.add(name, checkOK, strings.Join(profile.QAFields, ", "), "")
+	}
+}
+
+// authHint sugere a correção da autenticação de acordo com jira.auth.
+func authHint(cfg *config.Config) string {
+	switch cfg.JiraAuth {
+	case config.AuthBearer:
+		return "gere um novo personal access token no perfil do Jira e " +
+			"armazene-o com 'jira-reporter auth login'"
+	case config.AuthOAuth:
+		return "autorize novamente o app com 'jira-reporter auth login'"
+	default:
+		return "confira jira.email e gere um novo token em " +
+			"https://id.atlassian.com/manage-profile/security/api-tokens " +
+			"('jira-reporter auth login' o verifica e armazena)"
+	}
+}
+
+// firstLine retorna a primeira linha da mensagem do erro.
+func firstLine(err error) string {
+	line, _, _ := strings.Cut(err.Error(), "\n")
+	return line
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+
+	doctorCmd.Flags().StringP(
+		"path", "p", "", "Diretório dos relatórios verificado. Padrão: reports",
+	)
+	doctorCmd.Flags().String(
+		"template", "", "Template HTML verificado. Padrão: o usado nos relatórios",
+	)
+	doctorCmd.Flags().String(
+		"docx-engine", docxEngineNative,
+		"Motor DOCX usado nos relatórios (native ou libreoffice)",
+	)
+	doctorCmd.Flags().String(
+		"profile", "", "Perfil de consulta cujos campos de QA são verificados",
+	)
+}
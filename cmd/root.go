@@ -4,8 +4,12 @@ Copyright © 2025 Alan Gomes <alan.gomes.ag28@gmail.com>
 package cmd
 
 import (
+	"fmt"
+	"io"
 	"log"
+	"net/http"
 	"os"
+	"strings"
 
 	"github.com/alan-gomes1/jira-reporter/internal/config"
 	"github.com/alan-gomes1/jira-reporter/internal/model"
@@ -13,9 +17,14 @@ import (
 	"github.com/alan-gomes1/jira-reporter/internal/service"
 	"github.com/alan-gomes1/jira-reporter/internal/view"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
-const defaultTemplatePath = "template.html"
+// Motores disponíveis para geração de DOCX.
+const (
+	docxEngineNative      = "native"
+	docxEngineLibreOffice = "libreoffice"
+)
 
 var rootCmd = &cobra.Command{
 	Use:   "jira-reporter",
@@ -28,63 +37,294 @@ var rootCmd = &cobra.Command{
 // runReport é o handler principal que orquestra a geração do relatório.
 func runReport(cmd *cobra.Command, args []string) {
 	// Obtem as flags
-	reportName, _ := cmd.Flags().GetString("name")
-	reportPath, _ := cmd.Flags().GetString("path")
-	reportFormat, _ := cmd.Flags().GetString("format")
-	reportDate, _ := cmd.Flags().GetString("date")
-	includeQA, _ := cmd.Flags().GetBool("qa")
+	opts := reportOptionsFromFlags(cmd)
+	if toStdout, _ := cmd.Flags().GetBool("stdout"); toStdout {
+		opts.Output = os.Stdout
+	}
 
 	// Carrega as configurações
-	cfg, err := config.Load()
+	cfg, err := loadConfig(cmd)
 	if err != nil {
 		log.Fatalf("Erro ao carregar configurações: %v", err)
 	}
 
+	build, err := buildOptionsFromFlags(cmd, cfg)
+	if err != nil {
+		log.Fatalf("Erro ao inicializar serviços: %v", err)
+	}
+	opts.TemplatePath = build.templatePath
+
 	// Cria as dependências (Dependency Injection)
-	reportService, err := buildReportService(cfg)
+	reportService, err := buildReportService(cfg, build)
 	if err != nil {
 		log.Fatalf("Erro ao inicializar serviços: %v", err)
 	}
 
-	// Monta as opções do relatório
-	opts := model.ReportOptions{
+	// Gera o relatório
+	if err := reportService.Generate(opts); err != nil {
+		log.Fatalf("Erro ao gerar relatório: %v", err)
+	}
+}
+
+// loadConfig carrega a configuração com o arquivo, o contexto e os valores
+// informados nas flags --config, --context e --set.
+func loadConfig(cmd *cobra.Command) (*config.Config, error) {
+	opts, err := loadOptionsFromFlags(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return config.Load(opts)
+}
+
+// loadOptionsFromFlags monta as opções de carregamento da configuração a
+// partir das flags --config, --context e --set.
+func loadOptionsFromFlags(cmd *cobra.Command) (config.LoadOptions, error) {
+	file, _ := cmd.Flags().GetString("config")
+	context, _ := cmd.Flags().GetString("context")
+	assignments, _ := cmd.Flags().GetStringArray("set")
+
+	overrides := make(map[string]string, len(assignments))
+	for _, assignment := range assignments {
+		key, value, found := strings.Cut(assignment, "=")
+		if !found || strings.TrimSpace(key) == "" {
+			return config.LoadOptions{}, fmt.Errorf(
+				"valor inválido para --set: '%s'. Use chave=valor "+
+					"(ex: --set hourly_rate=150)", assignment,
+			)
+		}
+		overrides[strings.TrimSpace(key)] = value
+	}
+
+	return config.LoadOptions{
+		File:       file,
+		Context:    context,
+		Overrides:  overrides,
+		Passphrase: promptPassphrase,
+		Warn:       warnConfig,
+	}, nil
+}
+
+// warnConfig exibe os avisos do carregamento da configuração na saída de
+// erro, sem misturá-los ao relatório (ex: --stdout).
+func warnConfig(message string) {
+	fmt.Fprintf(os.Stderr, "Aviso: %s\n", message)
+}
+
+// promptPassphrase pede no terminal a senha do arquivo de credenciais.
+func promptPassphrase() (string, error) {
+	passphrase, err := promptSecret("Senha do arquivo de credenciais: ")
+	if err != nil {
+		return "", fmt.Errorf(
+			"%w (defina %s para usar o arquivo de credenciais sem terminal)",
+			err, config.PassphraseEnv,
+		)
+	}
+	return passphrase, nil
+}
+
+// promptSecret lê um valor do terminal sem exibi-lo. A pergunta vai para a
+// saída de erro, preservando a saída padrão (ex: --stdout).
+func promptSecret(label string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("a entrada padrão não é um terminal")
+	}
+
+	fmt.Fprint(os.Stderr, label)
+	secret, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("erro ao ler do terminal: %w", err)
+	}
+	return strings.TrimSpace(string(secret)), nil
+}
+
+// reportOptionsFromFlags monta as opções do relatório a partir das flags.
+func reportOptionsFromFlags(cmd *cobra.Command) model.ReportOptions {
+	reportName, _ := cmd.Flags().GetString("name")
+	reportPath, _ := cmd.Flags().GetString("path")
+	reportFormat, _ := cmd.Flags().GetString("format")
+	reportDate, _ := cmd.Flags().GetString("date")
+	reportFrom, _ := cmd.Flags().GetString("from")
+	reportTo, _ := cmd.Flags().GetString("to")
+	board, _ := cmd.Flags().GetString("board")
+	sprint, _ := cmd.Flags().GetString("sprint")
+	includeQA, _ := cmd.Flags().GetBool("qa")
+	includeHours, _ := cmd.Flags().GetBool("hours")
+	profile, _ := cmd.Flags().GetString("profile")
+	jql, _ := cmd.Flags().GetString("jql")
+
+	return model.ReportOptions{
 		Name:      reportName,
 		Path:      reportPath,
-		Format:    model.ReportFormat(reportFormat),
+		Formats:   model.ParseFormats(reportFormat),
 		Date:      reportDate,
+		From:      reportFrom,
+		To:        reportTo,
+		Board:     board,
+		Sprint:    sprint,
 		IncludeQA: includeQA,
+		Hours:     includeHours,
+		Profile:   profile,
+		JQL:       jql,
 	}
+}
 
-	// Gera o relatório
-	if err := reportService.Generate(opts); err != nil {
-		log.Fatalf("Erro ao gerar relatório: %v", err)
+// buildOptions reúne as opções informadas por flags para a construção das
+// dependências.
+type buildOptions struct {
+	docxEngine   string
+	csvBOM       bool         // Escreve o BOM UTF-8 no CSV
+	httpClient   *http.Client // nil = cliente HTTP padrão
+	templatePath string       // vazio = template embutido
+	progress     io.Writer    // Destino das mensagens de progresso
+}
+
+// buildOptionsFromFlags monta as opções de construção a partir das flags.
+func buildOptionsFromFlags(
+	cmd *cobra.Command, cfg *config.Config,
+) (buildOptions, error) {
+	docxEngine, _ := cmd.Flags().GetString("docx-engine")
+	csvBOM, _ := cmd.Flags().GetBool("csv-bom")
+	templateFlag, _ := cmd.Flags().GetString("template")
+	verbose, _ := cmd.Flags().GetBool("verbose")
+
+	// Com --stdout, a saída padrão fica apenas com o relatório
+	var progress io.Writer = os.Stdout
+	if toStdout, _ := cmd.Flags().GetBool("stdout"); toStdout {
+		progress = os.Stderr
 	}
+
+	httpClient, err := httpClientFromFlags(cmd, progress)
+	if err != nil {
+		return buildOptions{}, err
+	}
+
+	templatePath, err := resolveTemplatePath(templateFlag, cfg, verbose, progress)
+	if err != nil {
+		return buildOptions{}, err
+	}
+	if err := view.ValidateTemplates(templatePath); err != nil {
+		return buildOptions{}, fmt.Errorf("template inválido: %w", err)
+	}
+
+	return buildOptions{
+		docxEngine:   docxEngine,
+		csvBOM:       csvBOM,
+		httpClient:   httpClient,
+		templatePath: templatePath,
+		progress:     progress,
+	}, nil
 }
 
-// buildReportService constrói o ReportService com todas as dependências.
-func buildReportService(cfg *config.Config) (service.ReportService, error) {
+// httpClientFromFlags cria o cliente HTTP de gravação (--record) ou de
+// reprodução (--replay) das respostas do Jira. Sem as flags, retorna nil
+// para que o cliente padrão seja usado.
+func httpClientFromFlags(
+	cmd *cobra.Command, progress io.Writer,
+) (*http.Client, error) {
+	recordDir, _ := cmd.Flags().GetString("record")
+	replayDir, _ := cmd.Flags().GetString("replay")
+
+	switch {
+	case recordDir != "" && replayDir != "":
+		return nil, fmt.Errorf("use --record ou --replay, não ambos")
+	case recordDir != "":
+		return repository.NewRecordingClient(recordDir, progress)
+	case replayDir != "":
+		return repository.NewReplayClient(replayDir, progress)
+	default:
+		return nil, nil
+	}
+}
+
+// reportDependencies agrupa as dependências comuns aos serviços de relatório.
+type reportDependencies struct {
+	repo        repository.JiraRepository
+	dateService service.DateService
+	fileService service.FileService
+	generators  *view.Registry
+}
+
+// buildDependencies constrói o repositório, os serviços e os geradores.
+func buildDependencies(
+	cfg *config.Config, build buildOptions,
+) (*reportDependencies, error) {
 	// Repository
-	jiraRepo, err := repository.NewJiraRepository(cfg)
+	jiraRepo, err := repository.NewJiraRepository(
+		cfg, build.httpClient, build.progress,
+	)
 	if err != nil {
 		return nil, err
 	}
 
 	// Services
-	dateService := service.NewDateService()
+	location, err := jiraRepo.TimeZone()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao determinar o fuso horário: %w", err)
+	}
+	dateService := service.NewDateService(location)
 	fileService := service.NewFileService()
 
-	// Generators (Views)
-	generators := map[model.ReportFormat]view.ReportGenerator{
-		model.FormatHTML: view.NewHTMLGenerator(defaultTemplatePath),
-		model.FormatDOCX: view.NewDOCXGenerator(fileService),
+	// Generators (Views). O template HTML chega em cada geração pelas
+	// opções do relatório (ReportOptions.TemplatePath)
+	htmlGenerator := view.NewHTMLGenerator("")
+	docxGenerator, err := buildDOCXGenerator(build.docxEngine, htmlGenerator)
+	if err != nil {
+		return nil, err
+	}
+
+	generators := view.NewRegistry(
+		htmlGenerator,
+		docxGenerator,
+		view.NewPDFGenerator(),
+		view.NewMarkdownGenerator(),
+		view.NewCSVGenerator(build.csvBOM),
+		view.NewXLSXGenerator(),
+		view.NewJSONGenerator(),
+	)
+
+	return &reportDependencies{
+		repo:        jiraRepo,
+		dateService: dateService,
+		fileService: fileService,
+		generators:  generators,
+	}, nil
+}
+
+// buildReportService constrói o ReportService com todas as dependências.
+func buildReportService(
+	cfg *config.Config, build buildOptions,
+) (service.ReportService, error) {
+	deps, err := buildDependencies(cfg, build)
+	if err != nil {
+		return nil, err
 	}
 
 	reportService := service.NewReportService(
-		cfg, jiraRepo, dateService, fileService, generators,
+		cfg, deps.repo, deps.dateService, deps.fileService, deps.generators,
+		build.progress,
 	)
 	return reportService, nil
 }
 
+// buildDOCXGenerator escolhe o gerador DOCX de acordo com o motor informado.
+func buildDOCXGenerator(
+	engine string, htmlGenerator view.ReportGenerator,
+) (view.ReportGenerator, error) {
+	switch engine {
+	case docxEngineNative:
+		return view.NewDOCXGenerator(), nil
+	case docxEngineLibreOffice:
+		return view.NewLibreOfficeDOCXGenerator(htmlGenerator), nil
+	default:
+		return nil, fmt.Errorf(
+			"motor DOCX inválido: %s. Use '%s' ou '%s'",
+			engine, docxEngineNative, docxEngineLibreOffice,
+		)
+	}
+}
+
 // Execute executa o comando raiz.
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
@@ -93,20 +333,99 @@ func Execute() {
 }
 
 func init() {
-	rootCmd.Flags().StringP("name", "n", "", "Nome do relatório")
-	rootCmd.Flags().StringP(
+	rootCmd.PersistentFlags().BoolP(
+		"verbose", "v", false, "Exibe detalhes da execução",
+	)
+	rootCmd.PersistentFlags().String(
+		"config", "",
+		"Arquivo de configuração. Padrão: ~/.config/jira-reporter/config.yaml",
+	)
+	rootCmd.PersistentFlags().String(
+		"context", "",
+		"Contexto do arquivo de configuração (ex: um por cliente). "+
+			"Padrão: JIRA_REPORTER_CONTEXT ou current_context",
+	)
+	rootCmd.PersistentFlags().StringArray(
+		"set", nil,
+		"Define uma chave da configuração, com prioridade sobre o arquivo "+
+			"e o ambiente (ex: --set hourly_rate=150). Pode ser repetida",
+	)
+	addReportFlags(rootCmd)
+	rootCmd.Flags().Bool(
+		"stdout", false,
+		"Escreve o relatório na saída padrão em vez de salvar em arquivo "+
+			"(ex: jira-reporter -f json --stdout | jq)",
+	)
+}
+
+// addReportFlags registra as flags de geração de relatório no comando.
+func addReportFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("name", "n", "", "Nome do relatório")
+	cmd.Flags().StringP(
 		"path", "p", "", "Caminho onde será salvo o relatório",
 	)
-	rootCmd.Flags().StringP(
-		"format", "f", "html", "Formato do relatório (html ou docx)",
+	cmd.Flags().StringP(
+		"format", "f", "html", "Formatos do relatório separados por vírgula "+
+			"(html, docx, pdf, md, csv, xlsx ou json)",
 	)
-	rootCmd.Flags().StringP(
+	cmd.Flags().StringP(
 		"date", "d", "",
-		"Mês/ano do relatório no formato MM/YYYY (ex: 01/2025). "+
+		"Período do relatório: MM/YYYY (ex: 01/2025), semana ISO "+
+			"(ex: 2025-W14), trimestre (ex: Q1/2025) ou 'last N days'. "+
 			"Padrão: mês anterior",
 	)
-	rootCmd.Flags().BoolP(
+	cmd.Flags().String(
+		"from", "", "Início de um período livre (YYYY-MM-DD)",
+	)
+	cmd.Flags().String(
+		"to", "", "Fim de um período livre (YYYY-MM-DD). Padrão: hoje",
+	)
+	cmd.Flags().String(
+		"board", "", "Board do Jira (id ou nome) usado para resolver a sprint",
+	)
+	cmd.Flags().String(
+		"sprint", "",
+		"Gera o relatório de uma sprint: id, nome ou 'last' "+
+			"(última sprint encerrada do board)",
+	)
+	cmd.Flags().BoolP(
 		"qa", "q", false,
 		"Incluir cards onde o usuário está marcado como QA",
 	)
+	cmd.Flags().Bool(
+		"hours", false,
+		"Incluir as horas registradas (worklogs) por tarefa e por dia",
+	)
+	cmd.Flags().String(
+		"profile", "",
+		"Perfil de consulta JQL definido na configuração. "+
+			"Padrão: jql_profile ou 'default'",
+	)
+	cmd.Flags().String(
+		"jql", "",
+		"JQL avulsa que substitui o perfil (não combina com --sprint). "+
+			"Aceita os placeholders {{start}}, {{end}} e {{started}}",
+	)
+	cmd.Flags().String(
+		"docx-engine", docxEngineNative,
+		"Motor de geração do DOCX (native ou libreoffice)",
+	)
+	cmd.Flags().Bool(
+		"csv-bom", false,
+		"Inclui o BOM UTF-8 no CSV, para o Excel exibir os acentos corretamente",
+	)
+	cmd.Flags().String(
+		"template", "",
+		"Template HTML do relatório. Padrão: template_path, "+
+			"diretório de configuração, diretório atual ou template embutido",
+	)
+	cmd.Flags().String(
+		"record", "",
+		"Grava as respostas do Jira usadas na execução no diretório informado",
+	)
+	cmd.Flags().String(
+		"replay", "",
+		"Gera o relatório a partir das respostas gravadas no diretório "+
+			"informado, sem acessar o Jira",
+	)
 }
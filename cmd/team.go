@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/alan-gomes1/jira-reporter/internal/config"
+	"github.com/alan-gomes1/jira-reporter/internal/service"
+	"github.com/alan-gomes1/jira-reporter/internal/view"
+	"github.com/spf13/cobra"
+)
+
+var teamCmd = &cobra.Command{
+	Use:   "team",
+	Short: "Gera os relatórios de todos os membros da equipe",
+	Long: `Gera um relatório para cada membro da equipe configurado em
+ team.<id> no arquivo de configuração (ou em TEAM_MEMBER_<ID>_*), buscando
+ as issues pelo accountId de cada pessoa.
+ Opcionalmente, gera um resumo consolidado da equipe em HTML.`,
+	Run: runTeam,
+}
+
+// runTeam gera os relatórios dos membros da equipe.
+func runTeam(cmd *cobra.Command, args []string) {
+	// Obtem as flags
+	opts := reportOptionsFromFlags(cmd)
+	memberIDs, _ := cmd.Flags().GetStringSlice("member")
+	summary, _ := cmd.Flags().GetBool("summary")
+
+	// Carrega as configurações
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		log.Fatalf("Erro ao carregar configurações: %v", err)
+	}
+
+	members, err := cfg.TeamMembers(memberIDs)
+	if err != nil {
+		log.Fatalf("Erro ao carregar a equipe: %v", err)
+	}
+
+	build, err := buildOptionsFromFlags(cmd, cfg)
+	if err != nil {
+		log.Fatalf("Erro ao inicializar serviços: %v", err)
+	}
+	opts.TemplatePath = build.templatePath
+
+	// Cria as dependências (Dependency Injection)
+	teamService, err := buildTeamService(cfg, build)
+	if err != nil {
+		log.Fatalf("Erro ao inicializar serviços: %v", err)
+	}
+
+	// Gera os relatórios
+	if err := teamService.Generate(opts, members, summary); err != nil {
+		log.Fatalf("Erro ao gerar relatórios da equipe: %v", err)
+	}
+}
+
+// buildTeamService constrói o TeamService com todas as dependências.
+func buildTeamService(
+	cfg *config.Config, build buildOptions,
+) (service.TeamService, error) {
+	deps, err := buildDependencies(cfg, build)
+	if err != nil {
+		return nil, err
+	}
+
+	teamService := service.NewTeamService(
+		cfg, deps.repo, deps.dateService, deps.fileService, deps.generators,
+		view.NewTeamSummaryGenerator(), build.progress,
+	)
+	return teamService, nil
+}
+
+func init() {
+	rootCmd.AddCommand(teamCmd)
+
+	addReportFlags(teamCmd)
+	teamCmd.Flags().StringSlice(
+		"member", nil,
+		"Gera apenas os relatórios dos membros informados (ex: joao,maria)",
+	)
+	teamCmd.Flags().Bool(
+		"summary", false, "Gera também o resumo consolidado da equipe em HTML",
+	)
+}
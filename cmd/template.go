@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/alan-gomes1/jira-reporter/internal/config"
+)
+
+// Nomes usados na busca do template HTML.
+const (
+	templateFileName = "template.html"
+	templateDirName  = "templates"
+)
+
+// resolveTemplatePath determina o template HTML do relatório, que pode ser
+// um arquivo ou um diretório de templates com parciais. Um template
+// informado pela flag --template ou por template_path precisa existir; sem
+// eles, a busca segue a ordem: diretório de configuração do usuário,
+// diretório de trabalho e, por fim, o template embutido (caminho vazio).
+// Com verbose, a busca é descrita em progress.
+func resolveTemplatePath(
+	flagPath string, cfg *config.Config, verbose bool, progress io.Writer,
+) (string, error) {
+	logf := func(format string, args ...any) {
+		if verbose {
+			fmt.Fprintf(progress, "Template: "+format+"\n", args...)
+		}
+	}
+
+	overrides := []struct {
+		source string
+		path   string
+	}{
+		{"flag --template", flagPath},
+		{"template_path", cfg.TemplatePath},
+	}
+	for _, override := range overrides {
+		if override.path == "" {
+			continue
+		}
+		if !pathExists(override.path) {
+			return "", fmt.Errorf(
+				"template informado em %s não encontrado: %s",
+				override.source, override.path,
+			)
+		}
+		logf("usando %s (%s)", override.path, override.source)
+		return override.path, nil
+	}
+
+	var dirs []string
+	if dir, err := config.Dir(); err == nil {
+		dirs = append(dirs, dir)
+	}
+	dirs = append(dirs, ".")
+
+	// Em cada diretório, um diretório templates/ tem prioridade sobre o
+	// arquivo template.html
+	var candidates []string
+	for _, dir := range dirs {
+		candidates = append(
+			candidates,
+			filepath.Join(dir, templateDirName),
+			filepath.Join(dir, templateFileName),
+		)
+	}
+
+	for _, candidate := range candidates {
+		if pathExists(candidate) {
+			logf("usando %s", candidate)
+			return candidate, nil
+		}
+		logf("%s não encontrado", candidate)
+	}
+
+	logf("usando o template padrão embutido")
+	return "", nil
+}
+
+// pathExists verifica se o arquivo ou diretório existe.
+func pathExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
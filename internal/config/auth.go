@@ -0,0 +1,93 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Tipos de instalação do Jira, que definem a versão da API REST usada.
+const (
+	DeploymentCloud  = "cloud"  // Jira Cloud, API REST v3
+	DeploymentServer = "server" // Jira Server/Data Center, API REST v2
+)
+
+// Modos de autenticação na API do Jira.
+const (
+	AuthBasic  = "basic"  // E-mail (ou usuário) e token da API
+	AuthBearer = "bearer" // Personal access token (Jira Data Center)
+	AuthOAuth  = "oauth"  // OAuth 2.0 (3LO) do Jira Cloud, com refresh token
+)
+
+// DefaultOAuthRedirectURI é o endereço local que recebe o código de
+// autorização do OAuth no auth login. Precisa estar cadastrado no app do
+// Atlassian Developer Console.
+const DefaultOAuthRedirectURI = "http://localhost:8976/callback"
+
+// Deployments retorna os tipos de instalação do Jira aceitos.
+func Deployments() []string {
+	return []string{DeploymentCloud, DeploymentServer}
+}
+
+// AuthModes retorna os modos de autenticação aceitos.
+func AuthModes() []string {
+	return []string{AuthBasic, AuthBearer, AuthOAuth}
+}
+
+// validateAuth verifica o tipo de instalação, o modo de autenticação e os
+// dados exigidos pelo modo.
+func (c *Config) validateAuth() error {
+	var errs []error
+	if !contains(Deployments(), c.JiraDeployment) {
+		errs = append(errs, fmt.Errorf(
+			"jira.deployment inválido: %s. Use: %s",
+			c.JiraDeployment, strings.Join(Deployments(), ", "),
+		))
+	}
+
+	switch c.JiraAuth {
+	case AuthBasic:
+		if c.JiraEmail == "" {
+			errs = append(errs, missingError("jira.email"))
+		}
+	case AuthBearer:
+	case AuthOAuth:
+		if c.JiraDeployment == DeploymentServer {
+			errs = append(errs, fmt.Errorf(
+				"jira.auth %s é suportado apenas no Jira Cloud "+
+					"(use %s com um personal access token no Server/Data Center)",
+				AuthOAuth, AuthBearer,
+			))
+		}
+		if c.OAuthClientID == "" {
+			errs = append(errs, missingError("oauth.client_id"))
+		}
+		if c.OAuthClientSecret == "" {
+			errs = append(errs, missingError("oauth.client_secret"))
+		}
+		// O refresh token muda a cada renovação e precisa ser regravado
+		if c.TokenProvider != CredentialKeyring && c.TokenProvider != CredentialFile {
+			errs = append(errs, fmt.Errorf(
+				"jira.auth %s exige credentials.provider %s ou %s, onde o "+
+					"refresh token renovado é guardado",
+				AuthOAuth, CredentialKeyring, CredentialFile,
+			))
+		}
+	default:
+		errs = append(errs, fmt.Errorf(
+			"jira.auth inválido: %s. Use: %s",
+			c.JiraAuth, strings.Join(AuthModes(), ", "),
+		))
+	}
+	return errors.Join(errs...)
+}
+
+// contains verifica se o valor está na lista.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -1,39 +1,134 @@
 // Package config fornece configuração centralizada para a aplicação.
-// Implementa o padrão Singleton para carregar variáveis de ambiente uma única vez.
+// Implementa o padrão Singleton para carregar a configuração uma única vez,
+// combinando o arquivo de configuração, as variáveis de ambiente e as flags.
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
+	_ "time/tzdata" // Base de fusos horários embutida para timezone
 
+	"github.com/alan-gomes1/jira-reporter/internal/model"
 	"github.com/joho/godotenv"
 )
 
-// Config contém todas as configurações da aplicação carregadas do ambiente.
+// Config contém todas as configurações da aplicação.
 type Config struct {
 	// Jira API configuration
-	JiraURL   string
-	JiraEmail string
-	JiraToken string
+	JiraURL        string
+	JiraEmail      string
+	JiraToken      string // Token da API, personal access token ou refresh token (OAuth)
+	JiraDeployment string // Tipo de instalação: cloud (REST v3) ou server (REST v2)
+	JiraAuth       string // Modo de autenticação: basic, bearer ou oauth
+
+	// OAuth 2.0 (3LO) configuration
+	OAuthClientID     string // Client ID do app no Atlassian Developer Console
+	OAuthClientSecret string // Secret do app
+	OAuthRedirectURI  string // Endereço de retorno cadastrado no app
+	OAuthCloudID      string // Site do Jira no gateway (vazio = busca pelo jira.url)
+
+	// Credentials configuration
+	TokenProvider string // Origem do token quando jira.token é vazio: keyring, file ou command
+	TokenCommand  string // Comando que imprime o token (provedor command)
+	TokenFile     string // Arquivo criptografado de credenciais (provedor file)
 
 	// User/Company configuration
 	CompanyName string
 	CNPJ        string
 	Username    string
+
+	// Search configuration
+	SearchPageSize int // Quantidade de issues por página na busca do Jira
+	SearchMaxPages int // Limite de páginas buscadas por execução
+
+	// Description configuration
+	DescriptionMode      string // "full" (completa) ou "summary" (primeiro bloco)
+	DescriptionMaxLength int    // Limite de caracteres da descrição (0 = sem limite)
+
+	// Billing configuration
+	HourlyRate float64 // Valor da hora para o cálculo da fatura (0 = não exibe)
+
+	// Query profiles configuration
+	DefaultProfile string                        // Perfil usado quando --profile não é informado
+	Profiles       map[string]model.QueryProfile // Perfis de consulta por nome
+
+	// Time zone configuration
+	TimeZone string // Fuso horário IANA do relatório (vazio = fuso do perfil no Jira)
+
+	// Template configuration
+	TemplatePath string // Template HTML personalizado (vazio = busca padrão)
+
+	// Team configuration
+	Team []model.TeamMember // Membros da equipe, em ordem de identificador
+
+	// Source configuration
+	File    string            // Arquivo de configuração carregado (vazio = nenhum)
+	Context string            // Contexto do arquivo em uso (vazio = nenhum)
+	Sources map[string]string // Origem do valor de cada chave (ex: arquivo, variável)
+
+	values      map[string]value   // Valores das chaves, antes da conversão
+	credentials CredentialProvider // Provedor de onde o token foi obtido
+	passphrase  PassphraseFunc     // Senha do arquivo de credenciais
 }
 
+// LoadOptions são as escolhas da linha de comando para carregar a
+// configuração.
+type LoadOptions struct {
+	File      string            // Arquivo de configuração (vazio = arquivo padrão, opcional)
+	Context   string            // Contexto (vazio = JIRA_REPORTER_CONTEXT ou current_context)
+	Overrides map[string]string // Valores por chave informados nas flags (--set)
+
+	// Passphrase pede a senha do arquivo de credenciais quando
+	// JIRA_REPORTER_PASSPHRASE não está definida (nil = sem interação).
+	Passphrase PassphraseFunc
+	// Partial carrega os valores sem obter o token do provedor de
+	// credenciais nem validar a configuração (ex: auth login).
+	Partial bool
+	// Warn recebe os avisos do carregamento, como o uso de variáveis
+	// antigas no .env (nil = avisos ignorados).
+	Warn func(message string)
+}
+
+// Prefixos das variáveis antigas que definem perfis de consulta
+// (JQL_PROFILE_<NOME>_<CAMPO>) e membros da equipe (TEAM_MEMBER_<ID>_<CAMPO>).
+const (
+	profileEnvPrefix = "JQL_PROFILE_"
+	teamEnvPrefix    = "TEAM_MEMBER_"
+)
+
+// Valores padrão da paginação da busca no Jira.
+const (
+	DefaultSearchPageSize = 100
+	DefaultSearchMaxPages = 50
+)
+
+// sourceDefault identifica os valores padrão da aplicação.
+const sourceDefault = "padrão"
+
 var (
 	instance *Config
 	once     sync.Once
 	loadErr  error
 )
 
-// Load carrega as configurações do arquivo .env e variáveis de ambiente.
-// Utiliza sync.Once para garantir que o carregamento ocorra apenas uma vez.
-func Load() (*Config, error) {
+// value é o valor de uma chave da configuração junto com a sua origem.
+type value struct {
+	text   string
+	source string
+}
+
+// Load carrega a configuração. Utiliza sync.Once para garantir que o
+// carregamento ocorra apenas uma vez.
+func Load(opts LoadOptions) (*Config, error) {
 	once.Do(func() {
-		if err := godotenv.Load(); err != nil {
+		dotenv, err := godotenv.Read()
+		if err != nil {
 			// Não é um erro fatal se .env não existir,
 			// as variáveis podem estar no ambiente
 			if !os.IsNotExist(err) {
@@ -42,53 +137,526 @@ func Load() (*Config, error) {
 			}
 		}
 
-		instance = &Config{
-			JiraURL:     getEnvOrDefault("URL", ""),
-			JiraEmail:   getEnvOrDefault("EMAIL", ""),
-			JiraToken:   getEnvOrDefault("API_KEY", ""),
-			CompanyName: getEnvOrDefault("COMPANY_NAME", ""),
-			CNPJ:        getEnvOrDefault("CNPJ", ""),
-			Username:    getEnvOrDefault("USER_NAME", ""),
+		instance, loadErr = load(opts, os.Environ(), dotenv)
+	})
+
+	return instance, loadErr
+}
+
+// load monta a configuração a partir das camadas, da menor para a maior
+// prioridade: valores padrão, variáveis antigas sem prefixo (ex: URL),
+// arquivo de configuração (defaults e depois o contexto), variáveis
+// JIRA_REPORTER_* e, por fim, as flags. As variáveis antigas são lidas
+// apenas do .env, pois nomes como URL e USER_NAME são comuns no ambiente
+// do processo; nas variáveis JIRA_REPORTER_*, o ambiente do processo tem
+// prioridade sobre o .env.
+func load(
+	opts LoadOptions, environ []string, dotenv map[string]string,
+) (*Config, error) {
+	values := map[string]value{}
+	for _, s := range settings {
+		if s.defaultValue != "" {
+			values[s.key] = value{text: s.defaultValue, source: sourceDefault}
 		}
+	}
+
+	applyLegacyEnv(values, dotenv, opts.Warn)
 
-		if err := instance.Validate(); err != nil {
-			loadErr = err
-			instance = nil
+	env := mergeEnv(environ, dotenv)
+
+	file, path, err := readFile(opts.File)
+	if err != nil {
+		return nil, err
+	}
+
+	contextName := opts.Context
+	if contextName == "" {
+		contextName = env[contextEnv].text
+	}
+
+	var errs []error
+	selected := ""
+	if file != nil {
+		if selected, err = file.selectContext(contextName); err != nil {
+			return nil, err
 		}
-	})
+		errs = append(errs, applyFile(values, file.Defaults, path)...)
+		if selected != "" {
+			errs = append(errs, applyFile(
+				values, file.Contexts[selected],
+				fmt.Sprintf("%s (contexto %s)", path, selected),
+			)...)
+		}
+	} else if contextName != "" {
+		return nil, fmt.Errorf(
+			"contexto %s informado, mas nenhum arquivo de configuração foi "+
+				"encontrado", contextName,
+		)
+	}
 
-	return instance, loadErr
+	applyEnv(values, env)
+	errs = append(errs, applyOverrides(values, opts.Overrides)...)
+
+	cfg, buildErrs := build(values)
+	errs = append(errs, buildErrs...)
+	cfg.File = path
+	cfg.Context = selected
+
+	if opts.Partial {
+		if err := errors.Join(errs...); err != nil {
+			return nil, err
+		}
+		return cfg, nil
+	}
+
+	if err := cfg.Check(opts.Passphrase); err != nil {
+		errs = append(errs, err)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// mergeEnv combina as variáveis do .env e do ambiente do processo, que tem
+// prioridade. Variáveis vazias são ignoradas.
+func mergeEnv(environ []string, dotenv map[string]string) map[string]value {
+	env := map[string]value{}
+	for name, text := range dotenv {
+		if text != "" {
+			env[name] = value{text: text, source: ".env (" + name + ")"}
+		}
+	}
+	for _, entry := range environ {
+		name, text, found := strings.Cut(entry, "=")
+		if found && text != "" {
+			env[name] = value{
+				text: text, source: "variável de ambiente " + name,
+			}
+		}
+	}
+	return env
+}
+
+// applyEnv aplica as variáveis de ambiente com o prefixo JIRA_REPORTER_.
+func applyEnv(values map[string]value, env map[string]value) {
+	for name, v := range env {
+		if key, ok := keyFromEnv(name, false); ok {
+			values[key] = v
+		}
+	}
 }
 
-// Validate verifica se todas as configurações obrigatórias estão presentes.
+// applyLegacyEnv aplica as variáveis antigas, sem prefixo, definidas no
+// .env, avisando em warn qual variável substitui cada uma.
+func applyLegacyEnv(
+	values map[string]value, dotenv map[string]string, warn func(string),
+) {
+	env := mergeEnv(nil, dotenv)
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		key, ok := keyFromEnv(name, true)
+		if !ok {
+			continue
+		}
+		values[key] = env[name]
+		if warn != nil {
+			warn(fmt.Sprintf(
+				"a variável %s do .env está obsoleta, use %s",
+				name, EnvName(key),
+			))
+		}
+	}
+}
+
+// applyFile aplica uma seção do arquivo de configuração, rejeitando as
+// chaves desconhecidas.
+func applyFile(
+	values map[string]value, section map[string]any, source string,
+) []error {
+	var errs []error
+	for key, text := range flatten(section) {
+		if !IsKnownKey(key) {
+			errs = append(errs, fmt.Errorf(
+				"chave desconhecida em %s: %s", source, key,
+			))
+			continue
+		}
+		values[key] = value{text: text, source: source}
+	}
+	return errs
+}
+
+// applyOverrides aplica os valores informados nas flags.
+func applyOverrides(values map[string]value, overrides map[string]string) []error {
+	var errs []error
+	for key, text := range overrides {
+		if !IsKnownKey(key) {
+			errs = append(errs, fmt.Errorf("chave desconhecida em --set: %s", key))
+			continue
+		}
+		values[key] = value{text: text, source: "flag --set"}
+	}
+	return errs
+}
+
+// build converte os valores das chaves na configuração, retornando todos os
+// valores inválidos encontrados.
+func build(values map[string]value) (*Config, []error) {
+	var errs []error
+	text := func(key string) string {
+		return strings.TrimSpace(values[key].text)
+	}
+	integer := func(key string) int {
+		v := values[key]
+		parsed, err := strconv.Atoi(strings.TrimSpace(v.text))
+		if err != nil {
+			errs = append(errs, fmt.Errorf(
+				"valor inválido para %s em %s: '%s'", key, v.source, v.text,
+			))
+		}
+		return parsed
+	}
+	// Aceita vírgula ou ponto como separador decimal
+	decimal := func(key string) float64 {
+		v := values[key]
+		parsed, err := strconv.ParseFloat(
+			strings.ReplaceAll(strings.TrimSpace(v.text), ",", "."), 64,
+		)
+		if err != nil {
+			errs = append(errs, fmt.Errorf(
+				"valor inválido para %s em %s: '%s'", key, v.source, v.text,
+			))
+		}
+		return parsed
+	}
+
+	cfg := &Config{
+		JiraURL:              text("jira.url"),
+		JiraEmail:            text("jira.email"),
+		JiraToken:            text("jira.token"),
+		JiraDeployment:       strings.ToLower(text("jira.deployment")),
+		JiraAuth:             strings.ToLower(text("jira.auth")),
+		OAuthClientID:        text("oauth.client_id"),
+		OAuthClientSecret:    text("oauth.client_secret"),
+		OAuthRedirectURI:     text("oauth.redirect_uri"),
+		OAuthCloudID:         text("oauth.cloud_id"),
+		TokenProvider:        text("credentials.provider"),
+		TokenCommand:         text("credentials.command"),
+		TokenFile:            text("credentials.file"),
+		CompanyName:          text("company_name"),
+		CNPJ:                 text("cnpj"),
+		Username:             text("user_name"),
+		SearchPageSize:       integer("search.page_size"),
+		SearchMaxPages:       integer("search.max_pages"),
+		DescriptionMode:      text("description.mode"),
+		DescriptionMaxLength: integer("description.max_length"),
+		HourlyRate:           decimal("hourly_rate"),
+		DefaultProfile:       strings.ToLower(text("jql_profile")),
+		Profiles:             buildProfiles(values),
+		TimeZone:             text("timezone"),
+		TemplatePath:         text("template_path"),
+		Team:                 buildTeam(values),
+		Sources:              map[string]string{},
+		values:               values,
+	}
+	for key, v := range values {
+		cfg.Sources[key] = v.source
+	}
+	return cfg, errs
+}
+
+// buildProfiles monta os perfis de consulta das chaves
+// profiles.<nome>.<campo>. O perfil "default" sempre existe e pode ter seus
+// campos sobrescritos por profiles.default.<campo>. Os demais perfis herdam
+// os valores do perfil padrão para os campos não definidos.
+func buildProfiles(values map[string]value) map[string]model.QueryProfile {
+	defaults := model.NewDefaultQueryProfile()
+	applyProfileFields(&defaults, values)
+
+	profiles := map[string]model.QueryProfile{
+		model.DefaultProfileName: defaults,
+	}
+	for _, name := range profileGroup.names(values) {
+		if name == model.DefaultProfileName {
+			continue
+		}
+		profile := defaults
+		profile.Name = name
+		profile.Projects = nil
+		profile.Labels = nil
+		applyProfileFields(&profile, values)
+		profiles[name] = profile
+	}
+	return profiles
+}
+
+// applyProfileFields atribui ao perfil os campos definidos nas chaves.
+func applyProfileFields(profile *model.QueryProfile, values map[string]value) {
+	for _, field := range profileGroup.fields {
+		v, exists := values[profileGroup.key+"."+profile.Name+"."+field]
+		if !exists {
+			continue
+		}
+		switch field {
+		case "base":
+			profile.BaseJQL = strings.TrimSpace(v.text)
+		case "started_statuses":
+			profile.StartedStatuses = splitList(v.text)
+		case "qa_fields":
+			profile.QAFields = splitList(v.text)
+		case "projects":
+			profile.Projects = splitList(v.text)
+		case "labels":
+			profile.Labels = splitList(v.text)
+		}
+	}
+}
+
+// buildTeam monta os membros da equipe das chaves team.<id>.<campo>,
+// ordenados pelo identificador.
+func buildTeam(values map[string]value) []model.TeamMember {
+	names := teamGroup.names(values)
+	team := make([]model.TeamMember, 0, len(names))
+	for _, id := range names {
+		member := model.TeamMember{ID: id}
+		for _, field := range teamGroup.fields {
+			v := strings.TrimSpace(values[teamGroup.key+"."+id+"."+field].text)
+			switch field {
+			case "account_id":
+				member.AccountID = v
+			case "email":
+				member.Email = v
+			case "company_name":
+				member.User.CompanyName = v
+			case "cnpj":
+				member.User.CNPJ = v
+			case "user_name":
+				member.User.Username = v
+			}
+		}
+		team = append(team, member)
+	}
+	return team
+}
+
+// Check obtém o token do provedor de credenciais e valida a configuração,
+// completando uma configuração carregada com Partial (ex: no doctor, que
+// também precisa dos valores de uma configuração inválida).
+func (c *Config) Check(passphrase PassphraseFunc) error {
+	c.passphrase = passphrase
+
+	var errs []error
+	if err := c.resolveToken(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// Validate verifica se todas as configurações obrigatórias estão presentes
+// e são válidas, reportando todos os problemas encontrados de uma vez.
 func (c *Config) Validate() error {
-	if c.JiraURL == "" {
-		return fmt.Errorf("configuração obrigatória ausente: URL")
+	var errs []error
+
+	required := []struct {
+		key   string
+		value string
+	}{
+		{"jira.url", c.JiraURL},
+		{"company_name", c.CompanyName},
+		{"cnpj", c.CNPJ},
+		{"user_name", c.Username},
+	}
+	for _, field := range required {
+		if field.value == "" {
+			errs = append(errs, missingError(field.key))
+		}
+	}
+	// Com um provedor de credenciais, a falha ao obter o token já foi
+	// reportada
+	if c.JiraToken == "" && c.TokenProvider == "" {
+		errs = append(errs, missingError("jira.token"))
+	}
+	if err := c.validateAuth(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.validateCredentials(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if c.SearchPageSize < 1 {
+		errs = append(errs, fmt.Errorf("search.page_size deve ser maior que zero"))
 	}
-	if c.JiraEmail == "" {
-		return fmt.Errorf("configuração obrigatória ausente: EMAIL")
+	if c.SearchMaxPages < 1 {
+		errs = append(errs, fmt.Errorf("search.max_pages deve ser maior que zero"))
 	}
-	if c.JiraToken == "" {
-		return fmt.Errorf("configuração obrigatória ausente: API_KEY")
+	if !model.DescriptionMode(c.DescriptionMode).IsValid() {
+		errs = append(errs, fmt.Errorf(
+			"description.mode inválido: %s. Use 'full' ou 'summary'",
+			c.DescriptionMode,
+		))
 	}
-	if c.CompanyName == "" {
-		return fmt.Errorf("configuração obrigatória ausente: COMPANY_NAME")
+	if c.DescriptionMaxLength < 0 {
+		errs = append(errs, fmt.Errorf("description.max_length não pode ser negativo"))
 	}
-	if c.CNPJ == "" {
-		return fmt.Errorf("configuração obrigatória ausente: CNPJ")
+	if c.HourlyRate < 0 {
+		errs = append(errs, fmt.Errorf("hourly_rate não pode ser negativo"))
 	}
-	if c.Username == "" {
-		return fmt.Errorf("configuração obrigatória ausente: USER_NAME")
+	if c.TimeZone != "" {
+		if _, err := time.LoadLocation(c.TimeZone); err != nil {
+			errs = append(errs, fmt.Errorf(
+				"timezone inválido: %s. Use um nome IANA (ex: America/Sao_Paulo)",
+				c.TimeZone,
+			))
+		}
+	}
+	if _, err := c.Profile(c.DefaultProfile); err != nil {
+		errs = append(errs, fmt.Errorf("jql_profile inválido: %w", err))
+	}
+	for _, name := range c.ProfileNames() {
+		if err := validateProfile(c.Profiles[name]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, member := range c.Team {
+		if err := validateTeamMember(member); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// missingError indica uma chave obrigatória ausente, com a variável de
+// ambiente que pode defini-la.
+func missingError(key string) error {
+	return fmt.Errorf(
+		"configuração obrigatória ausente: %s (%s)", key, EnvName(key),
+	)
+}
+
+// validateProfile verifica se o perfil de consulta tem a JQL base e, quando
+// ela usa {{started}}, os status de início.
+func validateProfile(profile model.QueryProfile) error {
+	key := profileGroup.key + "." + profile.Name + "."
+	if strings.TrimSpace(profile.BaseJQL) == "" {
+		return fmt.Errorf(
+			"perfil de consulta '%s' sem JQL base (%s)",
+			profile.Name, key+"base",
+		)
+	}
+	usesStarted := strings.Contains(profile.BaseJQL, model.PlaceholderStarted)
+	if usesStarted && len(profile.StartedStatuses) == 0 {
+		return fmt.Errorf(
+			"perfil de consulta '%s' usa %s mas não define status de início (%s)",
+			profile.Name, model.PlaceholderStarted, key+"started_statuses",
+		)
 	}
 	return nil
 }
 
-// getEnvOrDefault retorna o valor da variável de ambiente ou um valor padrão.
-func getEnvOrDefault(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// validateTeamMember verifica se o membro da equipe tem os dados
+// obrigatórios.
+func validateTeamMember(member model.TeamMember) error {
+	key := teamGroup.key + "." + member.ID + "."
+	var errs []error
+	if member.AccountID == "" && member.Email == "" {
+		errs = append(errs, fmt.Errorf(
+			"membro da equipe '%s' sem accountId ou e-mail (%s ou %s)",
+			member.ID, key+"account_id", key+"email",
+		))
+	}
+
+	required := []struct {
+		value string
+		field string
+	}{
+		{member.User.CompanyName, "company_name"},
+		{member.User.CNPJ, "cnpj"},
+		{member.User.Username, "user_name"},
+	}
+	for _, field := range required {
+		if field.value == "" {
+			errs = append(errs, fmt.Errorf(
+				"configuração obrigatória ausente para o membro '%s': %s",
+				member.ID, key+field.field,
+			))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// TeamMembers retorna os membros da equipe com os identificadores
+// informados, ou todos quando nenhum identificador é informado.
+func (c *Config) TeamMembers(ids []string) ([]model.TeamMember, error) {
+	if len(c.Team) == 0 {
+		return nil, fmt.Errorf(
+			"nenhum membro da equipe configurado (%s.<id> no arquivo ou %s<ID>_*)",
+			teamGroup.key, teamEnvPrefix,
+		)
+	}
+	if len(ids) == 0 {
+		return c.Team, nil
+	}
+
+	members := make([]model.TeamMember, 0, len(ids))
+	for _, id := range ids {
+		found := false
+		for _, member := range c.Team {
+			if strings.EqualFold(member.ID, id) {
+				members = append(members, member)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("membro da equipe desconhecido: %s", id)
+		}
+	}
+	return members, nil
+}
+
+// Profile retorna o perfil de consulta com o nome informado.
+func (c *Config) Profile(name string) (model.QueryProfile, error) {
+	if name == "" {
+		name = c.DefaultProfile
+	}
+	profile, exists := c.Profiles[strings.ToLower(name)]
+	if !exists {
+		return model.QueryProfile{}, fmt.Errorf(
+			"perfil de consulta desconhecido: %s (disponíveis: %s)",
+			name, strings.Join(c.ProfileNames(), ", "),
+		)
+	}
+	return profile, nil
+}
+
+// ProfileNames retorna os nomes dos perfis de consulta em ordem alfabética.
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// splitList separa uma lista de valores separados por vírgula.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
-	return defaultValue
+	return items
 }
 
 // Reset limpa a instância singleton (útil para os testes).
@@ -0,0 +1,306 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// testConfigFile é um arquivo de configuração com dois clientes.
+const testConfigFile = `
+current_context: cliente-a
+defaults:
+  company_name: Empresa
+  cnpj: 00.000.000/0001-00
+  user_name: Responsável
+  jira:
+    email: eu@empresa.com
+    token: token-padrao
+  search:
+    page_size: 20
+contexts:
+  cliente-a:
+    jira:
+      url: https://cliente-a.atlassian.net
+    hourly_rate: 150,50
+    profiles:
+      Cliente:
+        base: "{{started}}"
+        started_statuses: [Em andamento, Doing]
+  cliente-b:
+    jira:
+      url: https://cliente-b.atlassian.net
+      token: token-b
+    cnpj: 11.111.111/0001-11
+    team:
+      joao:
+        email: joao@empresa.com
+        company_name: Empresa do João
+        cnpj: 22.222.222/0001-22
+        user_name: João
+`
+
+// writeConfigFile grava o conteúdo em um arquivo de configuração temporário
+// e isola o diretório de configuração do usuário.
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("erro ao gravar o arquivo de configuração: %v", err)
+	}
+	return path
+}
+
+func TestLoadUsesCurrentContextOverDefaults(t *testing.T) {
+	path := writeConfigFile(t, testConfigFile)
+
+	cfg, err := load(LoadOptions{File: path}, nil, nil)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	if cfg.Context != "cliente-a" {
+		t.Errorf("contexto esperado cliente-a, obtido %s", cfg.Context)
+	}
+	if cfg.JiraURL != "https://cliente-a.atlassian.net" {
+		t.Errorf("URL inesperada: %s", cfg.JiraURL)
+	}
+	if cfg.JiraToken != "token-padrao" || cfg.SearchPageSize != 20 {
+		t.Errorf("valores de defaults não aplicados: %+v", cfg)
+	}
+	if cfg.SearchMaxPages != DefaultSearchMaxPages {
+		t.Errorf("esperado o valor padrão de search.max_pages, obtido %d", cfg.SearchMaxPages)
+	}
+	if cfg.HourlyRate != 150.5 {
+		t.Errorf("valor da hora esperado 150.5, obtido %v", cfg.HourlyRate)
+	}
+
+	profile, err := cfg.Profile("cliente")
+	if err != nil {
+		t.Fatalf("perfil do contexto não carregado: %v", err)
+	}
+	if len(profile.StartedStatuses) != 2 || len(profile.QAFields) != 1 {
+		t.Errorf("perfil inesperado: %+v", profile)
+	}
+}
+
+func TestLoadSelectsContextFromOptionAndEnv(t *testing.T) {
+	path := writeConfigFile(t, testConfigFile)
+
+	environ := []string{contextEnv + "=cliente-b"}
+	cfg, err := load(LoadOptions{File: path}, environ, nil)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if cfg.CNPJ != "11.111.111/0001-11" || cfg.JiraToken != "token-b" {
+		t.Errorf("valores do contexto cliente-b não aplicados: %+v", cfg)
+	}
+	if len(cfg.Team) != 1 || cfg.Team[0].ID != "joao" {
+		t.Errorf("equipe do contexto não carregada: %+v", cfg.Team)
+	}
+
+	cfg, err = load(LoadOptions{File: path, Context: "cliente-a"}, environ, nil)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if cfg.Context != "cliente-a" {
+		t.Errorf("--context deveria ter prioridade, obtido %s", cfg.Context)
+	}
+
+	_, err = load(LoadOptions{File: path, Context: "cliente-c"}, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "cliente-a, cliente-b") {
+		t.Errorf("esperado erro listando os contextos, obtido %v", err)
+	}
+}
+
+func TestLoadAppliesLayersInOrder(t *testing.T) {
+	path := writeConfigFile(t, testConfigFile)
+
+	environ := []string{
+		// Variáveis antigas do ambiente do processo são ignoradas
+		"URL=https://shell.example.com",
+		"DESCRIPTION_MODE=full",
+		// Variáveis com prefixo ficam acima do arquivo
+		"JIRA_REPORTER_JIRA_TOKEN=token-env",
+		"JIRA_REPORTER_SEARCH_PAGE_SIZE=30",
+	}
+	dotenv := map[string]string{
+		// Variáveis antigas do .env ficam abaixo do arquivo
+		"HOURLY_RATE":                    "10",
+		"DESCRIPTION_MODE":               "summary",
+		"JIRA_REPORTER_SEARCH_PAGE_SIZE": "40",
+		"JIRA_REPORTER_SEARCH_MAX_PAGES": "5",
+	}
+	opts := LoadOptions{
+		File:      path,
+		Overrides: map[string]string{"jira.token": "token-flag"},
+	}
+
+	cfg, err := load(opts, environ, dotenv)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	checks := []struct {
+		key    string
+		got    any
+		want   any
+		source string
+	}{
+		{"jira.url", cfg.JiraURL, "https://cliente-a.atlassian.net", path + " (contexto cliente-a)"},
+		{"hourly_rate", cfg.HourlyRate, 150.5, path + " (contexto cliente-a)"},
+		{"description.mode", cfg.DescriptionMode, "summary", ".env (DESCRIPTION_MODE)"},
+		{"search.page_size", cfg.SearchPageSize, 30, "variável de ambiente JIRA_REPORTER_SEARCH_PAGE_SIZE"},
+		{"search.max_pages", cfg.SearchMaxPages, 5, ".env (JIRA_REPORTER_SEARCH_MAX_PAGES)"},
+		{"jira.token", cfg.JiraToken, "token-flag", "flag --set"},
+		{"jql_profile", cfg.DefaultProfile, "default", sourceDefault},
+	}
+	for _, check := range checks {
+		if check.got != check.want {
+			t.Errorf("%s: esperado %v, obtido %v", check.key, check.want, check.got)
+		}
+		if cfg.Sources[check.key] != check.source {
+			t.Errorf(
+				"%s: origem esperada %q, obtida %q",
+				check.key, check.source, cfg.Sources[check.key],
+			)
+		}
+	}
+}
+
+func TestLoadKeepsLegacyEnvironmentWithoutFile(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	dotenv := map[string]string{
+		"URL":                            "https://empresa.atlassian.net",
+		"EMAIL":                          "eu@empresa.com",
+		"API_KEY":                        "token",
+		"COMPANY_NAME":                   "Empresa",
+		"CNPJ":                           "00.000.000/0001-00",
+		"USER_NAME":                      "Responsável",
+		"JQL_PROFILE":                    "CLIENTE",
+		"JQL_PROFILE_CLIENTE_BASE":       "project = ABC",
+		"TEAM_MEMBER_MARIA_ACCOUNT_ID":   "abc",
+		"TEAM_MEMBER_MARIA_COMPANY_NAME": "Empresa da Maria",
+		"TEAM_MEMBER_MARIA_CNPJ":         "33.333.333/0001-33",
+		"TEAM_MEMBER_MARIA_USER_NAME":    "Maria",
+	}
+	// Os nomes antigos só são aceitos no .env, não no ambiente do processo
+	environ := []string{
+		"URL=https://shell.example.com",
+		"EMAIL=shell@example.com",
+		"USER_NAME=shell",
+		"CNPJ=99.999.999/0001-99",
+		"TIMEZONE=Asia/Tokyo",
+	}
+
+	var warnings []string
+	opts := LoadOptions{Warn: func(message string) {
+		warnings = append(warnings, message)
+	}}
+	cfg, err := load(opts, environ, dotenv)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if cfg.File != "" || cfg.JiraURL != "https://empresa.atlassian.net" {
+		t.Errorf("configuração inesperada: %+v", cfg)
+	}
+	if cfg.JiraEmail != "eu@empresa.com" || cfg.Username != "Responsável" ||
+		cfg.CNPJ != "00.000.000/0001-00" || cfg.TimeZone != "" {
+		t.Errorf("variáveis antigas do ambiente não deveriam ser lidas: %+v", cfg)
+	}
+	if profile, err := cfg.Profile(""); err != nil || profile.BaseJQL != "project = ABC" {
+		t.Errorf("perfil antigo não carregado: %+v, %v", profile, err)
+	}
+	if len(cfg.Team) != 1 || cfg.Team[0].AccountID != "abc" {
+		t.Errorf("equipe antiga não carregada: %+v", cfg.Team)
+	}
+
+	// Cada variável antiga do .env é avisada com o nome que a substitui
+	if len(warnings) != len(dotenv) {
+		t.Errorf("esperados %d avisos, obtido %d: %v", len(dotenv), len(warnings), warnings)
+	}
+	expected := "a variável URL do .env está obsoleta, use JIRA_REPORTER_JIRA_URL"
+	if !slices.Contains(warnings, expected) {
+		t.Errorf("esperado o aviso %q, obtido %v", expected, warnings)
+	}
+}
+
+func TestLoadReportsEveryProblemAtOnce(t *testing.T) {
+	path := writeConfigFile(t, `
+defaults:
+  search:
+    page_size: muitas
+  descripton:
+    mode: full
+  timezone: Lua/Base
+`)
+
+	_, err := load(LoadOptions{File: path}, nil, nil)
+	if err == nil {
+		t.Fatal("esperado erro de configuração")
+	}
+
+	expected := []string{
+		"chave desconhecida em " + path + ": descripton.mode",
+		"valor inválido para search.page_size",
+		"configuração obrigatória ausente: jira.url (JIRA_REPORTER_JIRA_URL)",
+		"configuração obrigatória ausente: jira.token (JIRA_REPORTER_JIRA_TOKEN)",
+		"configuração obrigatória ausente: user_name (JIRA_REPORTER_USER_NAME)",
+		"timezone inválido: Lua/Base",
+	}
+	for _, message := range expected {
+		if !strings.Contains(err.Error(), message) {
+			t.Errorf("mensagem %q ausente em:\n%v", message, err)
+		}
+	}
+}
+
+func TestLoadValidatesAuthModes(t *testing.T) {
+	path := writeConfigFile(t, `
+defaults:
+  company_name: Empresa
+  cnpj: 00.000.000/0001-00
+  user_name: Responsável
+contexts:
+  datacenter:
+    jira:
+      url: https://jira.empresa.com
+      deployment: Server
+      auth: bearer
+      token: pat
+  oauth-server:
+    jira:
+      url: https://jira.empresa.com
+      deployment: server
+      auth: oauth
+      token: refresh
+`)
+
+	cfg, err := load(LoadOptions{File: path, Context: "datacenter"}, nil, nil)
+	if err != nil {
+		t.Fatalf("bearer sem e-mail deveria ser aceito: %v", err)
+	}
+	if cfg.JiraDeployment != DeploymentServer || cfg.JiraAuth != AuthBearer {
+		t.Errorf("modo inesperado: %s, %s", cfg.JiraDeployment, cfg.JiraAuth)
+	}
+
+	_, err = load(LoadOptions{File: path, Context: "oauth-server"}, nil, nil)
+	if err == nil {
+		t.Fatal("esperado erro de configuração do OAuth")
+	}
+	expected := []string{
+		"jira.auth oauth é suportado apenas no Jira Cloud",
+		"configuração obrigatória ausente: oauth.client_id",
+		"jira.auth oauth exige credentials.provider",
+	}
+	for _, message := range expected {
+		if !strings.Contains(err.Error(), message) {
+			t.Errorf("mensagem %q ausente em:\n%v", message, err)
+		}
+	}
+}
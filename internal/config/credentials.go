@@ -0,0 +1,488 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/zalando/go-keyring"
+)
+
+// Provedores de credenciais do token da API do Jira.
+const (
+	CredentialKeyring = "keyring" // Chaveiro do sistema (Secret Service no Linux)
+	CredentialFile    = "file"    // Arquivo criptografado com uma senha
+	CredentialCommand = "command" // Comando externo (ex: pass, op read)
+)
+
+// CredentialsFileName é o nome padrão do arquivo criptografado de
+// credenciais, no diretório de configuração.
+const CredentialsFileName = "credentials.enc"
+
+// PassphraseEnv fornece a senha do arquivo de credenciais sem interação
+// (ex: em CI).
+const PassphraseEnv = EnvPrefix + "PASSPHRASE"
+
+// credentialService identifica as credenciais da aplicação no chaveiro do
+// sistema.
+const credentialService = "jira-reporter"
+
+// Parâmetros da criptografia do arquivo de credenciais: chave AES-256
+// derivada da senha com PBKDF2-SHA256.
+const (
+	credentialsVersion    = 1
+	credentialsIterations = 600000
+	credentialsKeyLength  = 32
+	credentialsSaltLength = 16
+)
+
+// CredentialProvider obtém e armazena o token da API do Jira fora do
+// arquivo de configuração. Cada token é identificado pela conta (ver
+// Config.CredentialAccount).
+type CredentialProvider interface {
+	// Name retorna o nome do provedor (ex: keyring).
+	Name() string
+	// Token retorna o token armazenado para a conta.
+	Token(account string) (string, error)
+	// Store armazena o token da conta.
+	Store(account, token string) error
+}
+
+// PassphraseFunc obtém a senha do arquivo de credenciais (ex: digitada no
+// terminal).
+type PassphraseFunc func() (string, error)
+
+// CredentialProviders retorna os nomes dos provedores de credenciais.
+func CredentialProviders() []string {
+	return []string{CredentialKeyring, CredentialFile, CredentialCommand}
+}
+
+// NewCredentialProvider cria o provedor de credenciais com o nome informado,
+// usando o comando e o arquivo da configuração. A senha do arquivo vem de
+// JIRA_REPORTER_PASSPHRASE ou, na falta dela, de passphrase.
+func NewCredentialProvider(
+	name string, cfg *Config, passphrase PassphraseFunc,
+) (CredentialProvider, error) {
+	if err := checkCredentialProvider(name, cfg.TokenCommand); err != nil {
+		return nil, err
+	}
+
+	switch name {
+	case CredentialKeyring:
+		return &keyringProvider{}, nil
+	case CredentialCommand:
+		return &commandProvider{command: cfg.TokenCommand}, nil
+	default:
+		path := cfg.TokenFile
+		if path == "" {
+			dir, err := Dir()
+			if err != nil {
+				return nil, fmt.Errorf(
+					"erro ao determinar o arquivo de credenciais: %w", err,
+				)
+			}
+			path = filepath.Join(dir, CredentialsFileName)
+		}
+		return &fileProvider{path: path, passphrase: passphrase}, nil
+	}
+}
+
+// CredentialAccount retorna a conta que identifica o token nos provedores:
+// o site do Jira e o e-mail (ex: empresa.atlassian.net/ana@empresa.com), ou
+// apenas o site quando não há e-mail (ex: personal access token).
+func (c *Config) CredentialAccount() string {
+	host := c.JiraURL
+	if parsed, err := url.Parse(c.JiraURL); err == nil && parsed.Host != "" {
+		host = parsed.Host
+	}
+	if c.JiraEmail == "" {
+		return host
+	}
+	return host + "/" + strings.ToLower(c.JiraEmail)
+}
+
+// resolveToken obtém o token do provedor configurado quando jira.token não é
+// informado diretamente. Sem o site do Jira não há conta, e a validação
+// reporta a ausência.
+func (c *Config) resolveToken() error {
+	if c.JiraToken != "" || c.TokenProvider == "" {
+		return nil
+	}
+	if c.JiraURL == "" || c.validateCredentials() != nil {
+		return nil
+	}
+
+	provider, err := c.credentialProvider()
+	if err != nil {
+		return err
+	}
+	token, err := provider.Token(c.CredentialAccount())
+	if err != nil {
+		return fmt.Errorf(
+			"erro ao obter o token da API (%s): %w", provider.Name(), err,
+		)
+	}
+
+	c.JiraToken = token
+	c.Sources["jira.token"] = "provedor " + provider.Name()
+	return nil
+}
+
+// StoreToken armazena um novo token no provedor de credenciais configurado
+// e passa a usá-lo (ex: o refresh token renovado do OAuth).
+func (c *Config) StoreToken(token string) error {
+	if c.TokenProvider == "" {
+		return fmt.Errorf(
+			"nenhum provedor de credenciais configurado (credentials.provider)",
+		)
+	}
+
+	provider, err := c.credentialProvider()
+	if err != nil {
+		return err
+	}
+	if err := provider.Store(c.CredentialAccount(), token); err != nil {
+		return err
+	}
+	c.JiraToken = token
+	return nil
+}
+
+// credentialProvider retorna o provedor configurado, criado uma única vez
+// para que a senha do arquivo de credenciais seja pedida apenas uma vez.
+func (c *Config) credentialProvider() (CredentialProvider, error) {
+	if c.credentials != nil {
+		return c.credentials, nil
+	}
+
+	provider, err := NewCredentialProvider(c.TokenProvider, c, c.passphrase)
+	if err != nil {
+		return nil, err
+	}
+	c.credentials = provider
+	return provider, nil
+}
+
+// validateCredentials verifica o provedor de credenciais configurado.
+func (c *Config) validateCredentials() error {
+	if c.TokenProvider == "" {
+		return nil
+	}
+	return checkCredentialProvider(c.TokenProvider, c.TokenCommand)
+}
+
+// checkCredentialProvider verifica o nome do provedor e o comando exigido
+// pelo provedor command.
+func checkCredentialProvider(name, command string) error {
+	switch name {
+	case CredentialKeyring, CredentialFile:
+		return nil
+	case CredentialCommand:
+		if command == "" {
+			return fmt.Errorf(
+				"credentials.command é obrigatório com o provedor %s",
+				CredentialCommand,
+			)
+		}
+		return nil
+	default:
+		return fmt.Errorf(
+			"credentials.provider inválido: %s. Use: %s",
+			name, strings.Join(CredentialProviders(), ", "),
+		)
+	}
+}
+
+// keyringProvider armazena o token no chaveiro do sistema: Secret Service
+// (D-Bus) no Linux, Keychain no macOS e Credential Manager no Windows.
+type keyringProvider struct{}
+
+// Name retorna o nome do provedor.
+func (p *keyringProvider) Name() string {
+	return CredentialKeyring
+}
+
+// Token retorna o token da conta armazenado no chaveiro.
+func (p *keyringProvider) Token(account string) (string, error) {
+	token, err := keyring.Get(credentialService, account)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", fmt.Errorf(
+			"nenhum token no chaveiro do sistema para %s. "+
+				"Use 'jira-reporter auth login'", account,
+		)
+	}
+	if err != nil {
+		return "", fmt.Errorf("erro ao ler o chaveiro do sistema: %w", err)
+	}
+	return token, nil
+}
+
+// Store armazena o token da conta no chaveiro.
+func (p *keyringProvider) Store(account, token string) error {
+	if err := keyring.Set(credentialService, account, token); err != nil {
+		return fmt.Errorf("erro ao gravar no chaveiro do sistema: %w", err)
+	}
+	return nil
+}
+
+// commandProvider obtém o token da primeira linha da saída de um comando
+// externo, como um gerenciador de senhas (ex: pass show jira/token ou
+// op read op://Privado/Jira/token).
+type commandProvider struct {
+	command string
+}
+
+// Name retorna o nome do provedor.
+func (p *commandProvider) Name() string {
+	return CredentialCommand
+}
+
+// Token executa o comando e retorna a primeira linha da saída. O terminal
+// fica disponível para o comando pedir a senha do gerenciador.
+func (p *commandProvider) Token(account string) (string, error) {
+	cmd := shellCommand(p.command)
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = os.Stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("erro ao executar '%s': %w", p.command, err)
+	}
+
+	line, _, _ := strings.Cut(string(output), "\n")
+	token := strings.TrimSpace(line)
+	if token == "" {
+		return "", fmt.Errorf("o comando '%s' não retornou um token", p.command)
+	}
+	return token, nil
+}
+
+// Store não é suportado: o token é mantido pelo gerenciador de senhas.
+func (p *commandProvider) Store(account, token string) error {
+	return fmt.Errorf(
+		"o provedor %s é somente leitura: armazene o token no gerenciador "+
+			"de senhas (ex: pass insert)", CredentialCommand,
+	)
+}
+
+// shellCommand cria o comando executado pelo shell do sistema.
+func shellCommand(command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", command)
+	}
+	return exec.Command("sh", "-c", command)
+}
+
+// fileProvider armazena os tokens, por conta, em um arquivo criptografado
+// com AES-256-GCM, cuja chave é derivada de uma senha.
+type fileProvider struct {
+	path       string
+	passphrase PassphraseFunc
+	secret     string // Senha obtida na primeira leitura ou gravação
+}
+
+// credentialsFile é o conteúdo gravado no arquivo de credenciais. Os dados
+// criptografados são um objeto JSON com o token de cada conta.
+type credentialsFile struct {
+	Version    int    `json:"version"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Data       []byte `json:"data"`
+}
+
+// Name retorna o nome do provedor.
+func (p *fileProvider) Name() string {
+	return CredentialFile
+}
+
+// Token retorna o token da conta armazenado no arquivo.
+func (p *fileProvider) Token(account string) (string, error) {
+	tokens, err := p.read()
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf(
+			"arquivo de credenciais não encontrado: %s. "+
+				"Use 'jira-reporter auth login --provider file'", p.path,
+		)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	token, exists := tokens[account]
+	if !exists {
+		return "", fmt.Errorf(
+			"nenhum token em %s para %s. Use 'jira-reporter auth login "+
+				"--provider file'", p.path, account,
+		)
+	}
+	return token, nil
+}
+
+// Store armazena o token da conta no arquivo, preservando os das demais
+// contas.
+func (p *fileProvider) Store(account, token string) error {
+	tokens, err := p.read()
+	if errors.Is(err, os.ErrNotExist) {
+		tokens = map[string]string{}
+	} else if err != nil {
+		return err
+	}
+	tokens[account] = token
+
+	return p.write(tokens)
+}
+
+// read descriptografa o arquivo de credenciais.
+func (p *fileProvider) read() (map[string]string, error) {
+	content, err := os.ReadFile(p.path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file credentialsFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf(
+			"arquivo de credenciais inválido (%s): %w", p.path, err,
+		)
+	}
+	if file.Version != credentialsVersion {
+		return nil, fmt.Errorf(
+			"versão do arquivo de credenciais não suportada: %d", file.Version,
+		)
+	}
+
+	// Um arquivo adulterado não pode enfraquecer a derivação da chave
+	if file.Iterations < credentialsIterations ||
+		len(file.Salt) < credentialsSaltLength {
+		return nil, fmt.Errorf(
+			"arquivo de credenciais corrompido (%s): parâmetros de "+
+				"criptografia inválidos", p.path,
+		)
+	}
+
+	aead, err := p.cipher(file.Salt, file.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	// aead.Open entra em pânico com um nonce de tamanho diferente
+	if len(file.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf(
+			"arquivo de credenciais corrompido (%s): nonce inválido", p.path,
+		)
+	}
+	plain, err := aead.Open(nil, file.Nonce, file.Data, nil)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"senha incorreta ou arquivo de credenciais corrompido (%s)", p.path,
+		)
+	}
+
+	tokens := map[string]string{}
+	if err := json.Unmarshal(plain, &tokens); err != nil {
+		return nil, fmt.Errorf(
+			"arquivo de credenciais inválido (%s): %w", p.path, err,
+		)
+	}
+	return tokens, nil
+}
+
+// write criptografa os tokens com um novo salt e grava o arquivo, legível
+// apenas pelo usuário.
+func (p *fileProvider) write(tokens map[string]string) error {
+	plain, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+
+	salt := make([]byte, credentialsSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	aead, err := p.cipher(salt, credentialsIterations)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	content, err := json.MarshalIndent(credentialsFile{
+		Version:    credentialsVersion,
+		Iterations: credentialsIterations,
+		Salt:       salt,
+		Nonce:      nonce,
+		Data:       aead.Seal(nil, nonce, plain, nil),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p.path), 0o700); err != nil {
+		return fmt.Errorf("erro ao criar o diretório de credenciais: %w", err)
+	}
+	if err := os.WriteFile(p.path, content, 0o600); err != nil {
+		return fmt.Errorf("erro ao gravar o arquivo de credenciais: %w", err)
+	}
+	return nil
+}
+
+// cipher deriva a chave da senha e cria a cifra AES-256-GCM.
+func (p *fileProvider) cipher(salt []byte, iterations int) (cipher.AEAD, error) {
+	secret, err := p.secretPassphrase()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := pbkdf2.Key(
+		sha256.New, secret, salt, iterations, credentialsKeyLength,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao derivar a chave das credenciais: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// secretPassphrase obtém a senha uma única vez: de JIRA_REPORTER_PASSPHRASE
+// ou da função informada.
+func (p *fileProvider) secretPassphrase() (string, error) {
+	if p.secret != "" {
+		return p.secret, nil
+	}
+
+	secret := os.Getenv(PassphraseEnv)
+	if secret == "" {
+		if p.passphrase == nil {
+			return "", fmt.Errorf(
+				"senha do arquivo de credenciais não informada (%s)",
+				PassphraseEnv,
+			)
+		}
+		var err error
+		if secret, err = p.passphrase(); err != nil {
+			return "", err
+		}
+	}
+	if secret == "" {
+		return "", fmt.Errorf("a senha do arquivo de credenciais não pode ser vazia")
+	}
+
+	p.secret = secret
+	return secret, nil
+}
@@ -0,0 +1,152 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testCredentialsConfig é um arquivo de configuração sem o token, obtido
+// pelo provedor de credenciais.
+const testCredentialsConfig = `
+defaults:
+  company_name: Empresa
+  cnpj: 00.000.000/0001-00
+  user_name: Responsável
+  jira:
+    url: https://empresa.atlassian.net
+    email: Eu@Empresa.com
+  credentials:
+    provider: command
+    command: "printf 'token-comando\\nlogin: eu@empresa.com\\n'"
+`
+
+func TestFileCredentialProviderRoundTrip(t *testing.T) {
+	t.Setenv(PassphraseEnv, "")
+	cfg := &Config{TokenFile: filepath.Join(t.TempDir(), CredentialsFileName)}
+	passphrase := func(secret string) PassphraseFunc {
+		return func() (string, error) { return secret, nil }
+	}
+
+	provider, err := NewCredentialProvider(CredentialFile, cfg, passphrase("segredo"))
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if err := provider.Store("a.atlassian.net/eu@a.com", "token-a"); err != nil {
+		t.Fatalf("erro ao armazenar: %v", err)
+	}
+	if err := provider.Store("b.atlassian.net/eu@b.com", "token-b"); err != nil {
+		t.Fatalf("erro ao armazenar: %v", err)
+	}
+
+	reader, _ := NewCredentialProvider(CredentialFile, cfg, passphrase("segredo"))
+	for account, want := range map[string]string{
+		"a.atlassian.net/eu@a.com": "token-a",
+		"b.atlassian.net/eu@b.com": "token-b",
+	} {
+		token, err := reader.Token(account)
+		if err != nil || token != want {
+			t.Errorf("%s: esperado %s, obtido %q (%v)", account, want, token, err)
+		}
+	}
+
+	wrong, _ := NewCredentialProvider(CredentialFile, cfg, passphrase("errada"))
+	if _, err := wrong.Token("a.atlassian.net/eu@a.com"); err == nil ||
+		!strings.Contains(err.Error(), "senha incorreta") {
+		t.Errorf("esperado erro de senha incorreta, obtido %v", err)
+	}
+}
+
+func TestFileCredentialProviderRejectsCorruptedFile(t *testing.T) {
+	t.Setenv(PassphraseEnv, "")
+	path := filepath.Join(t.TempDir(), CredentialsFileName)
+	cfg := &Config{TokenFile: path}
+	passphrase := func() (string, error) { return "segredo", nil }
+
+	provider, err := NewCredentialProvider(CredentialFile, cfg, passphrase)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if err := provider.Store("a.atlassian.net/eu@a.com", "token-a"); err != nil {
+		t.Fatalf("erro ao armazenar: %v", err)
+	}
+	original, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		corrupt func(file *credentialsFile) // nil grava conteúdo que não é JSON
+		err     string
+	}{
+		{"conteúdo inválido", nil, "arquivo de credenciais inválido"},
+		{"versão desconhecida", func(f *credentialsFile) { f.Version = 99 }, "versão do arquivo de credenciais não suportada"},
+		{"nonce curto", func(f *credentialsFile) { f.Nonce = f.Nonce[:4] }, "nonce inválido"},
+		{"nonce ausente", func(f *credentialsFile) { f.Nonce = nil }, "nonce inválido"},
+		{"poucas iterações", func(f *credentialsFile) { f.Iterations = 1000 }, "parâmetros de criptografia inválidos"},
+		{"iterações negativas", func(f *credentialsFile) { f.Iterations = -1 }, "parâmetros de criptografia inválidos"},
+		{"salt curto", func(f *credentialsFile) { f.Salt = f.Salt[:2] }, "parâmetros de criptografia inválidos"},
+		{"dados alterados", func(f *credentialsFile) { f.Data[0] ^= 0xff }, "arquivo de credenciais corrompido"},
+		{"dados truncados", func(f *credentialsFile) { f.Data = f.Data[:3] }, "arquivo de credenciais corrompido"},
+	}
+	for _, tt := range tests {
+		content := []byte("{corrompido")
+		if tt.corrupt != nil {
+			var file credentialsFile
+			if err := json.Unmarshal(original, &file); err != nil {
+				t.Fatal(err)
+			}
+			tt.corrupt(&file)
+			content, _ = json.Marshal(file)
+		}
+		if err := os.WriteFile(path, content, 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		reader, _ := NewCredentialProvider(CredentialFile, cfg, passphrase)
+		_, err := reader.Token("a.atlassian.net/eu@a.com")
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: esperado erro contendo %q, obtido %v", tt.name, tt.err, err)
+		}
+	}
+}
+
+func TestLoadResolvesTokenFromCredentialProvider(t *testing.T) {
+	path := writeConfigFile(t, testCredentialsConfig)
+
+	cfg, err := load(LoadOptions{File: path}, nil, nil)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if cfg.JiraToken != "token-comando" {
+		t.Errorf("esperada a primeira linha da saída do comando, obtido %q", cfg.JiraToken)
+	}
+	if cfg.Sources["jira.token"] != "provedor command" {
+		t.Errorf("origem inesperada: %q", cfg.Sources["jira.token"])
+	}
+	if account := cfg.CredentialAccount(); account != "empresa.atlassian.net/eu@empresa.com" {
+		t.Errorf("conta inesperada: %s", account)
+	}
+
+	// Um token informado diretamente dispensa o provedor
+	opts := LoadOptions{
+		File:      path,
+		Overrides: map[string]string{"credentials.command": "exit 1"},
+	}
+	environ := []string{"JIRA_REPORTER_JIRA_TOKEN=token-env"}
+	cfg, err = load(opts, environ, nil)
+	if err != nil || cfg.JiraToken != "token-env" {
+		t.Errorf("esperado o token do ambiente, obtido %+v (%v)", cfg, err)
+	}
+
+	_, err = load(opts, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "erro ao obter o token da API (command)") {
+		t.Errorf("esperado erro do provedor, obtido %v", err)
+	}
+	if err != nil && strings.Contains(err.Error(), "ausente: jira.token") {
+		t.Errorf("falha do provedor não deveria ser reportada como ausência: %v", err)
+	}
+}
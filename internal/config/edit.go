@@ -0,0 +1,169 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// KeyValue é o valor de uma chave a ser gravado no arquivo de configuração.
+type KeyValue struct {
+	Key   string
+	Value string
+}
+
+// SetFileValues grava os valores no arquivo de configuração, na seção
+// defaults ou no contexto informado, criando o arquivo, o contexto e as
+// seções que faltarem. Os demais valores e os comentários do arquivo são
+// preservados. Sem caminho informado, usa o arquivo padrão. Retorna o
+// caminho do arquivo gravado.
+func SetFileValues(path, context string, values ...KeyValue) (string, error) {
+	for _, kv := range values {
+		if !IsKnownKey(kv.Key) {
+			return "", fmt.Errorf("chave desconhecida: %s", kv.Key)
+		}
+	}
+
+	return editFile(path, func(root *yaml.Node) {
+		var section *yaml.Node
+		if context == "" {
+			section = mappingChild(root, "defaults", false)
+		} else {
+			contexts := mappingChild(root, "contexts", false)
+			section = mappingChild(contexts, context, false)
+		}
+
+		for _, kv := range values {
+			node := section
+			parts := strings.Split(kv.Key, ".")
+			for i, part := range parts[:len(parts)-1] {
+				// Os nomes dos perfis e dos membros não diferenciam
+				// maiúsculas de minúsculas
+				group := i > 0 && isGroupKey(strings.Join(parts[:i], "."))
+				node = mappingChild(node, part, group)
+			}
+			setScalar(mappingChild(node, parts[len(parts)-1], false), kv.Value)
+		}
+	})
+}
+
+// SetCurrentContext define o current_context do arquivo de configuração,
+// que fica no início do arquivo quando ainda não existe. Retorna o caminho
+// do arquivo gravado.
+func SetCurrentContext(path, context string) (string, error) {
+	return editFile(path, func(root *yaml.Node) {
+		node := mappingChild(root, "current_context", false)
+		if last := len(root.Content) - 1; root.Content[last] == node && last > 1 {
+			// Chave recém-criada: move o par para o início
+			root.Content = append(root.Content[last-1:], root.Content[:last-1]...)
+		}
+		setScalar(node, context)
+	})
+}
+
+// editFile aplica a alteração ao documento YAML do arquivo de configuração
+// e grava o resultado, depois de verificar que ele continua válido.
+func editFile(path string, edit func(root *yaml.Node)) (string, error) {
+	if path == "" {
+		var err error
+		if path, err = DefaultFile(); err != nil {
+			return "", fmt.Errorf("erro ao localizar o arquivo de configuração: %w", err)
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("erro ao ler o arquivo de configuração: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return "", fmt.Errorf(
+			"arquivo de configuração inválido (%s): %w", path, err,
+		)
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{
+			Kind:    yaml.DocumentNode,
+			Content: []*yaml.Node{{Kind: yaml.MappingNode}},
+		}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return "", fmt.Errorf(
+			"arquivo de configuração inválido (%s): esperado um mapa de chaves", path,
+		)
+	}
+
+	edit(root)
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return "", fmt.Errorf("erro ao gerar o arquivo de configuração: %w", err)
+	}
+
+	// Garante que o arquivo gravado continua legível pela aplicação
+	var check fileConfig
+	decoder := yaml.NewDecoder(bytes.NewReader(out.Bytes()))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&check); err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf(
+			"arquivo de configuração inválido (%s): %w", path, err,
+		)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", fmt.Errorf("erro ao criar o diretório de configuração: %w", err)
+	}
+	if err := os.WriteFile(path, out.Bytes(), 0o600); err != nil {
+		return "", fmt.Errorf("erro ao gravar o arquivo de configuração: %w", err)
+	}
+	return path, nil
+}
+
+// mappingChild retorna o valor da chave no mapa, criando-o quando não
+// existe. Um nó que não é um mapa (ex: "defaults:" sem filhos) é convertido
+// em mapa. Com fold, a chave é comparada sem diferenciar maiúsculas de
+// minúsculas e criada em minúsculas.
+func mappingChild(node *yaml.Node, key string, fold bool) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		node.Kind = yaml.MappingNode
+		node.Tag = ""
+		node.Value = ""
+		node.Style = 0
+		node.Content = nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name := node.Content[i].Value
+		if name == key || (fold && strings.EqualFold(name, key)) {
+			return node.Content[i+1]
+		}
+	}
+
+	if fold {
+		key = strings.ToLower(key)
+	}
+	child := &yaml.Node{Kind: yaml.MappingNode}
+	node.Content = append(node.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Value: key}, child,
+	)
+	return child
+}
+
+// setScalar substitui o valor do nó por um texto, mantendo os comentários.
+func setScalar(node *yaml.Node, text string) {
+	node.Kind = yaml.ScalarNode
+	node.Tag = "!!str"
+	node.Value = text
+	node.Style = 0
+	node.Content = nil
+}
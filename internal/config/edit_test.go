@@ -0,0 +1,98 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetFileValuesPreservesFileAndCreatesContext(t *testing.T) {
+	path := writeConfigFile(t, testConfigFile+"# comentário final\n")
+
+	_, err := SetFileValues(path, "cliente-c",
+		KeyValue{Key: "jira.url", Value: "https://cliente-c.atlassian.net"},
+		KeyValue{Key: "hourly_rate", Value: "200"},
+		KeyValue{Key: "profiles.CLIENTE.base", Value: "project = C"},
+	)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if _, err := SetFileValues(path, "", KeyValue{Key: "cnpj", Value: "99"}); err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if _, err := SetCurrentContext(path, "cliente-c"); err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("erro ao ler o arquivo: %v", err)
+	}
+	if !strings.Contains(string(content), "# comentário final") {
+		t.Errorf("comentário perdido:\n%s", content)
+	}
+
+	cfg, err := load(LoadOptions{File: path}, nil, nil)
+	if err != nil {
+		t.Fatalf("erro ao carregar o arquivo gravado: %v", err)
+	}
+	if cfg.Context != "cliente-c" || cfg.JiraURL != "https://cliente-c.atlassian.net" {
+		t.Errorf("contexto inesperado: %s, %s", cfg.Context, cfg.JiraURL)
+	}
+	if cfg.HourlyRate != 200 || cfg.CNPJ != "99" || cfg.JiraToken != "token-padrao" {
+		t.Errorf("valores inesperados: %+v", cfg)
+	}
+	if profile, err := cfg.Profile("cliente"); err != nil || profile.BaseJQL != "project = C" {
+		t.Errorf("perfil não gravado: %+v, %v", profile, err)
+	}
+
+	if _, err := SetFileValues(path, "", KeyValue{Key: "jira.senha", Value: "x"}); err == nil {
+		t.Error("esperado erro para chave desconhecida")
+	}
+}
+
+func TestSetFileValuesCreatesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "novo", FileName)
+
+	if _, err := SetFileValues(path, "", KeyValue{Key: "search.page_size", Value: "50"}); err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("arquivo não criado: %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("permissão esperada 0600, obtida %o", info.Mode().Perm())
+	}
+}
+
+func TestEntriesMaskSecretsAndShowSources(t *testing.T) {
+	path := writeConfigFile(t, testConfigFile)
+
+	cfg, err := load(LoadOptions{
+		File:      path,
+		Overrides: map[string]string{"jira.token": "ATATT3xFfGF0-segredo-1234"},
+	}, nil, nil)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	entries := map[string]Entry{}
+	for _, entry := range cfg.Entries() {
+		entries[entry.Key] = entry
+	}
+	if token := entries["jira.token"]; token.Value != "********1234" ||
+		token.Source != "flag --set" {
+		t.Errorf("token não mascarado: %+v", token)
+	}
+	if url := entries["jira.url"]; !strings.Contains(url.Source, "contexto cliente-a") {
+		t.Errorf("origem inesperada: %+v", url)
+	}
+	if base := entries["profiles.cliente.base"]; base.Value != "{{started}}" {
+		t.Errorf("chave do perfil ausente: %+v", base)
+	}
+	if unset := entries["template_path"]; unset.Source != "" || unset.Value != "" {
+		t.Errorf("chave não definida com valor: %+v", unset)
+	}
+}
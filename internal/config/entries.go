@@ -0,0 +1,70 @@
+package config
+
+import (
+	"sort"
+	"strings"
+)
+
+// Entry é o valor efetivo de uma chave da configuração e a sua origem.
+type Entry struct {
+	Key    string
+	Value  string // Valor, mascarado nas chaves secretas
+	Source string // Origem do valor (vazio = chave não definida)
+}
+
+// Entries retorna os valores efetivos das chaves simples, na ordem de
+// exibição, seguidos das chaves dos perfis e da equipe definidas. Os valores
+// secretos são mascarados e um token obtido do provedor de credenciais é
+// indicado como tal.
+func (c *Config) Entries() []Entry {
+	entries := make([]Entry, 0, len(settings))
+	for _, s := range settings {
+		entry := c.entry(s.key)
+		if s.key == "jira.token" && entry.Source == "" && c.TokenProvider != "" {
+			entry.Value = "(no provedor de credenciais)"
+			entry.Source = "credentials.provider " + c.TokenProvider
+		}
+		entries = append(entries, entry)
+	}
+
+	var grouped []string
+	for key := range c.values {
+		for _, group := range settingGroups {
+			if _, _, ok := group.split(key); ok {
+				grouped = append(grouped, key)
+			}
+		}
+	}
+	sort.Strings(grouped)
+	for _, key := range grouped {
+		entries = append(entries, c.entry(key))
+	}
+	return entries
+}
+
+// entry retorna o valor e a origem da chave, mascarando os secretos.
+func (c *Config) entry(key string) Entry {
+	v, exists := c.values[key]
+	if !exists {
+		return Entry{Key: key}
+	}
+	text := v.text
+	if IsSecretKey(key) {
+		text = MaskSecret(text)
+	}
+	return Entry{Key: key, Value: text, Source: v.source}
+}
+
+// MaskSecret oculta um valor secreto, mantendo apenas os quatro últimos
+// caracteres dos valores longos o bastante para identificá-los.
+func MaskSecret(secret string) string {
+	secret = strings.TrimSpace(secret)
+	switch {
+	case secret == "":
+		return ""
+	case len(secret) < 12:
+		return "********"
+	default:
+		return "********" + secret[len(secret)-4:]
+	}
+}
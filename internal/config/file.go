@@ -0,0 +1,163 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Nomes do diretório e do arquivo de configuração do usuário.
+const (
+	DirName  = "jira-reporter"
+	FileName = "config.yaml"
+)
+
+// Dir retorna o diretório de configuração do usuário
+// (ex: ~/.config/jira-reporter, respeitando XDG_CONFIG_HOME).
+func Dir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, DirName), nil
+}
+
+// DefaultFile retorna o caminho padrão do arquivo de configuração.
+func DefaultFile() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, FileName), nil
+}
+
+// fileConfig é o conteúdo do arquivo de configuração: valores comuns a
+// todos os contextos e um conjunto de valores por contexto (ex: um por
+// cliente, cada um com o seu site do Jira e CNPJ).
+type fileConfig struct {
+	CurrentContext string                    `yaml:"current_context"`
+	Defaults       map[string]any            `yaml:"defaults"`
+	Contexts       map[string]map[string]any `yaml:"contexts"`
+}
+
+// readFile lê o arquivo de configuração. Sem caminho informado, usa o
+// arquivo padrão, que é opcional; um caminho informado precisa existir.
+// Retorna nil quando não há arquivo.
+func readFile(path string) (*fileConfig, string, error) {
+	explicit := path != ""
+	if !explicit {
+		var err error
+		if path, err = DefaultFile(); err != nil {
+			return nil, "", nil
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && !explicit {
+			return nil, "", nil
+		}
+		return nil, "", fmt.Errorf("erro ao ler o arquivo de configuração: %w", err)
+	}
+
+	var file fileConfig
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, "", fmt.Errorf(
+			"arquivo de configuração inválido (%s): %w", path, err,
+		)
+	}
+	return &file, path, nil
+}
+
+// selectContext determina o contexto usado: o informado (--context ou
+// JIRA_REPORTER_CONTEXT), o current_context do arquivo ou, quando o arquivo
+// tem um único contexto, esse contexto.
+func (f *fileConfig) selectContext(name string) (string, error) {
+	if name == "" {
+		name = f.CurrentContext
+	}
+	if name == "" {
+		if len(f.Contexts) == 1 {
+			for only := range f.Contexts {
+				return only, nil
+			}
+		}
+		return "", nil
+	}
+
+	if _, exists := f.Contexts[name]; !exists {
+		return "", fmt.Errorf(
+			"contexto desconhecido: %s (disponíveis: %s)",
+			name, strings.Join(f.contextNames(), ", "),
+		)
+	}
+	return name, nil
+}
+
+// contextNames retorna os nomes dos contextos em ordem alfabética.
+func (f *fileConfig) contextNames() []string {
+	names := make([]string, 0, len(f.Contexts))
+	for name := range f.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// flatten converte uma seção do arquivo em chaves com ponto
+// (ex: jira: {url: ...} resulta em jira.url). Listas viram valores
+// separados por vírgula. Os nomes dos perfis e dos membros da equipe são
+// convertidos para minúsculas, como nas variáveis de ambiente.
+func flatten(section map[string]any) map[string]string {
+	flat := map[string]string{}
+	if section == nil {
+		return flat
+	}
+	var walk func(prefix string, node any)
+	walk = func(prefix string, node any) {
+		switch value := node.(type) {
+		case map[string]any:
+			for key, child := range value {
+				if isGroupKey(prefix) {
+					key = strings.ToLower(key)
+				}
+				if prefix != "" {
+					key = prefix + "." + key
+				}
+				walk(key, child)
+			}
+		case []any:
+			items := make([]string, 0, len(value))
+			for _, item := range value {
+				items = append(items, fmt.Sprint(item))
+			}
+			flat[prefix] = strings.Join(items, ",")
+		case nil:
+			flat[prefix] = ""
+		default:
+			flat[prefix] = fmt.Sprint(value)
+		}
+	}
+	walk("", section)
+	return flat
+}
+
+// isGroupKey verifica se a chave é o prefixo de um grupo (ex: profiles),
+// cujos filhos são nomes e não campos.
+func isGroupKey(key string) bool {
+	for _, group := range settingGroups {
+		if group.key == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,229 @@
+package config
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+)
+
+// EnvPrefix é o prefixo das variáveis de ambiente da aplicação. Cada chave
+// da configuração corresponde a uma variável com o prefixo, em maiúsculas e
+// com "_" no lugar de "." (ex: jira.url em JIRA_REPORTER_JIRA_URL).
+const EnvPrefix = "JIRA_REPORTER_"
+
+// contextEnv seleciona o contexto quando --context não é informado.
+const contextEnv = EnvPrefix + "CONTEXT"
+
+// setting descreve uma chave simples da configuração.
+type setting struct {
+	key          string // Chave no arquivo (ex: jira.url)
+	legacy       string // Variável antiga, sem prefixo, só no .env (ex: URL); vazio = nenhuma
+	defaultValue string // Valor usado quando nenhuma camada define a chave
+	secret       bool   // Valor mascarado ao exibir a configuração
+}
+
+// settings são as chaves simples da configuração, na ordem de exibição.
+var settings = []setting{
+	{key: "jira.url", legacy: "URL"},
+	{key: "jira.email", legacy: "EMAIL"},
+	{key: "jira.token", legacy: "API_KEY", secret: true},
+	{key: "jira.deployment", defaultValue: DeploymentCloud},
+	{key: "jira.auth", defaultValue: AuthBasic},
+	{key: "oauth.client_id"},
+	{key: "oauth.client_secret", secret: true},
+	{key: "oauth.redirect_uri", defaultValue: DefaultOAuthRedirectURI},
+	{key: "oauth.cloud_id"},
+	{key: "credentials.provider"},
+	{key: "credentials.command"},
+	{key: "credentials.file"},
+	{key: "company_name", legacy: "COMPANY_NAME"},
+	{key: "cnpj", legacy: "CNPJ"},
+	{key: "user_name", legacy: "USER_NAME"},
+	{
+		key: "search.page_size", legacy: "SEARCH_PAGE_SIZE",
+		defaultValue: strconv.Itoa(DefaultSearchPageSize),
+	},
+	{
+		key: "search.max_pages", legacy: "SEARCH_MAX_PAGES",
+		defaultValue: strconv.Itoa(DefaultSearchMaxPages),
+	},
+	{
+		key: "description.mode", legacy: "DESCRIPTION_MODE",
+		defaultValue: string(model.DescriptionFull),
+	},
+	{key: "description.max_length", legacy: "DESCRIPTION_MAX_LENGTH", defaultValue: "0"},
+	{key: "hourly_rate", legacy: "HOURLY_RATE", defaultValue: "0"},
+	{key: "timezone", legacy: "TIMEZONE"},
+	{key: "template_path", legacy: "TEMPLATE_PATH"},
+	{key: "jql_profile", legacy: "JQL_PROFILE", defaultValue: model.DefaultProfileName},
+}
+
+// settingGroup descreve chaves com um nome variável no meio, como os perfis
+// de consulta (profiles.<nome>.<campo>) e os membros da equipe
+// (team.<id>.<campo>).
+type settingGroup struct {
+	key       string   // Prefixo da chave (ex: profiles)
+	envPrefix string   // Prefixo da variável com JIRA_REPORTER_ (ex: PROFILES_)
+	legacy    string   // Prefixo da variável antiga, só no .env (ex: JQL_PROFILE_)
+	fields    []string // Campos aceitos (ex: base, started_statuses)
+}
+
+// Grupos de chaves da configuração.
+var (
+	profileGroup = settingGroup{
+		key:       "profiles",
+		envPrefix: "PROFILES_",
+		legacy:    profileEnvPrefix,
+		fields: []string{
+			"base", "started_statuses", "qa_fields", "projects", "labels",
+		},
+	}
+	teamGroup = settingGroup{
+		key:       "team",
+		envPrefix: "TEAM_",
+		legacy:    teamEnvPrefix,
+		fields: []string{
+			"account_id", "email", "company_name", "cnpj", "user_name",
+		},
+	}
+	settingGroups = []settingGroup{profileGroup, teamGroup}
+)
+
+// EnvName retorna a variável de ambiente correspondente à chave
+// (ex: jira.url resulta em JIRA_REPORTER_JIRA_URL).
+func EnvName(key string) string {
+	for _, group := range settingGroups {
+		if name, field, ok := group.split(key); ok {
+			return EnvPrefix + group.envPrefix +
+				strings.ToUpper(name+"_"+field)
+		}
+	}
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// IsKnownKey verifica se a chave pertence à configuração.
+func IsKnownKey(key string) bool {
+	for _, s := range settings {
+		if s.key == key {
+			return true
+		}
+	}
+	for _, group := range settingGroups {
+		if _, _, ok := group.split(key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSecretKey verifica se o valor da chave é secreto (ex: jira.token).
+func IsSecretKey(key string) bool {
+	for _, s := range settings {
+		if s.key == key {
+			return s.secret
+		}
+	}
+	return false
+}
+
+// Keys retorna as chaves simples da configuração, na ordem de exibição.
+func Keys() []string {
+	keys := make([]string, len(settings))
+	for i, s := range settings {
+		keys[i] = s.key
+	}
+	return keys
+}
+
+// split separa a chave do grupo no nome e no campo
+// (ex: profiles.cliente.base resulta em cliente e base).
+func (g settingGroup) split(key string) (name, field string, ok bool) {
+	rest, found := strings.CutPrefix(key, g.key+".")
+	if !found {
+		return "", "", false
+	}
+	name, field, found = strings.Cut(rest, ".")
+	if !found || name == "" || !g.hasField(field) {
+		return "", "", false
+	}
+	return name, field, true
+}
+
+// hasField verifica se o campo pertence ao grupo.
+func (g settingGroup) hasField(field string) bool {
+	for _, f := range g.fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// fromEnv converte uma variável do grupo (sem o prefixo informado) na
+// chave correspondente (ex: CLIENTE_BASE resulta em profiles.cliente.base).
+func (g settingGroup) fromEnv(name string) (string, bool) {
+	for _, field := range g.fields {
+		suffix := "_" + strings.ToUpper(field)
+		if !strings.HasSuffix(name, suffix) {
+			continue
+		}
+		id := strings.ToLower(strings.TrimSuffix(name, suffix))
+		if id == "" {
+			return "", false
+		}
+		return g.key + "." + id + "." + field, true
+	}
+	return "", false
+}
+
+// names retorna os nomes definidos no grupo (ex: os perfis), em ordem.
+func (g settingGroup) names(values map[string]value) []string {
+	seen := map[string]bool{}
+	var names []string
+	for key := range values {
+		if name, _, ok := g.split(key); ok && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// keyFromEnv converte o nome de uma variável de ambiente na chave da
+// configuração. Com legacy, aceita as variáveis antigas sem prefixo
+// (ex: URL e JQL_PROFILE_<NOME>_<CAMPO>); sem legacy, apenas as variáveis
+// com o prefixo JIRA_REPORTER_.
+func keyFromEnv(name string, legacy bool) (string, bool) {
+	if legacy {
+		for _, s := range settings {
+			if s.legacy != "" && s.legacy == name {
+				return s.key, true
+			}
+		}
+		for _, group := range settingGroups {
+			if rest, found := strings.CutPrefix(name, group.legacy); found {
+				return group.fromEnv(rest)
+			}
+		}
+		return "", false
+	}
+
+	rest, found := strings.CutPrefix(name, EnvPrefix)
+	if !found {
+		return "", false
+	}
+	for _, s := range settings {
+		if EnvName(s.key) == name {
+			return s.key, true
+		}
+	}
+	for _, group := range settingGroups {
+		if groupRest, found := strings.CutPrefix(rest, group.envPrefix); found {
+			return group.fromEnv(groupRest)
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,349 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// BlockType identifica o tipo de um bloco da descrição.
+type BlockType string
+
+const (
+	BlockParagraph   BlockType = "paragraph"
+	BlockHeading     BlockType = "heading"
+	BlockBulletList  BlockType = "bulletList"
+	BlockOrderedList BlockType = "orderedList"
+	BlockCode        BlockType = "codeBlock"
+	BlockQuote       BlockType = "blockquote"
+	BlockRule        BlockType = "rule"
+)
+
+// truncationSuffix é adicionado ao texto cortado pelo limite de tamanho.
+const truncationSuffix = "…"
+
+// Description representa a descrição de uma issue como uma árvore de blocos
+// independente de formato, que cada gerador renderiza à sua maneira.
+type Description struct {
+	Blocks []Block `json:"blocks,omitempty"`
+}
+
+// Block representa um bloco da descrição (parágrafo, lista, código etc.).
+type Block struct {
+	Type     BlockType  `json:"type"`
+	Level    int        `json:"level,omitempty"`    // Nível do título (1-6)
+	Language string     `json:"language,omitempty"` // Linguagem do bloco de código
+	Start    int        `json:"start,omitempty"`    // Número inicial da lista ordenada
+	Text     string     `json:"text,omitempty"`     // Conteúdo do bloco de código
+	Inlines  []Inline   `json:"inlines,omitempty"`  // Conteúdo de parágrafos e títulos
+	Items    []ListItem `json:"items,omitempty"`    // Itens de listas
+	Children []Block    `json:"children,omitempty"` // Conteúdo de citações
+}
+
+// ListStart retorna o número inicial de uma lista ordenada (padrão 1).
+func (b Block) ListStart() int {
+	if b.Start < 1 {
+		return 1
+	}
+	return b.Start
+}
+
+// ListItem representa um item de lista, que pode conter vários blocos.
+type ListItem struct {
+	Blocks []Block `json:"blocks"`
+}
+
+// Inline representa um trecho de texto com sua formatação.
+type Inline struct {
+	Text      string `json:"text,omitempty"`
+	Bold      bool   `json:"bold,omitempty"`
+	Italic    bool   `json:"italic,omitempty"`
+	Underline bool   `json:"underline,omitempty"`
+	Strike    bool   `json:"strike,omitempty"`
+	Code      bool   `json:"code,omitempty"`
+	Link      string `json:"link,omitempty"`
+	Mention   bool   `json:"mention,omitempty"`
+	Break     bool   `json:"break,omitempty"` // Quebra de linha forçada
+}
+
+// DescriptionMode define como a descrição é exibida no relatório.
+type DescriptionMode string
+
+const (
+	// DescriptionFull exibe a descrição completa.
+	DescriptionFull DescriptionMode = "full"
+	// DescriptionSummary exibe apenas o primeiro bloco da descrição.
+	DescriptionSummary DescriptionMode = "summary"
+)
+
+// IsValid verifica se o modo de descrição é válido.
+func (m DescriptionMode) IsValid() bool {
+	return m == DescriptionFull || m == DescriptionSummary
+}
+
+// NewTextDescription cria uma descrição a partir de texto simples,
+// separando parágrafos por linhas em branco.
+func NewTextDescription(text string) Description {
+	var desc Description
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+
+		var inlines []Inline
+		for i, line := range strings.Split(paragraph, "\n") {
+			if i > 0 {
+				inlines = append(inlines, Inline{Break: true})
+			}
+			inlines = append(inlines, Inline{Text: line})
+		}
+		desc.Blocks = append(desc.Blocks, Block{
+			Type: BlockParagraph, Inlines: inlines,
+		})
+	}
+	return desc
+}
+
+// IsEmpty verifica se a descrição não possui conteúdo.
+func (d Description) IsEmpty() bool {
+	return strings.TrimSpace(d.PlainText()) == ""
+}
+
+// String retorna a descrição como texto simples.
+func (d Description) String() string {
+	return d.PlainText()
+}
+
+// PlainText converte a descrição em texto simples, separando os blocos
+// por linhas em branco e marcando itens de lista com "-" ou "1.".
+func (d Description) PlainText() string {
+	return strings.Join(blocksPlainText(d.Blocks, ""), "\n\n")
+}
+
+// Shape aplica o modo e o tamanho máximo (em caracteres, 0 = sem limite)
+// à descrição, retornando uma nova descrição.
+func (d Description) Shape(mode DescriptionMode, maxLength int) Description {
+	shaped := d
+	if mode == DescriptionSummary {
+		shaped = d.Summary()
+	}
+	if maxLength > 0 {
+		shaped, _ = shaped.Truncate(maxLength)
+	}
+	return shaped
+}
+
+// Summary retorna apenas o primeiro bloco com conteúdo da descrição.
+func (d Description) Summary() Description {
+	for _, block := range d.Blocks {
+		if len(blocksPlainText([]Block{block}, "")) > 0 {
+			return Description{Blocks: []Block{block}}
+		}
+	}
+	return Description{}
+}
+
+// Truncate limita a descrição a maxLength caracteres de texto, cortando
+// o último trecho. Quando algum conteúdo é descartado (inclusive blocos
+// inteiros após um limite atingido exatamente no fim de um bloco), o último
+// trecho mantido termina com reticências e o retorno indica o corte.
+func (d Description) Truncate(maxLength int) (Description, bool) {
+	remaining := maxLength
+	blocks, cut := truncateBlocks(d.Blocks, &remaining)
+	if cut {
+		blocks = appendTruncationSuffix(blocks)
+	}
+	return Description{Blocks: blocks}, cut
+}
+
+// InlinesPlainText concatena o texto de trechos de um parágrafo.
+func InlinesPlainText(inlines []Inline) string {
+	var b strings.Builder
+	for _, inline := range inlines {
+		if inline.Break {
+			b.WriteString("\n")
+			continue
+		}
+		b.WriteString(inline.Text)
+	}
+	return b.String()
+}
+
+// blocksPlainText converte blocos em linhas de texto com o recuo informado.
+func blocksPlainText(blocks []Block, indent string) []string {
+	var parts []string
+	for _, block := range blocks {
+		var text string
+		switch block.Type {
+		case BlockParagraph, BlockHeading:
+			text = InlinesPlainText(block.Inlines)
+		case BlockCode:
+			text = block.Text
+		case BlockQuote:
+			text = strings.Join(blocksPlainText(block.Children, indent), "\n")
+		case BlockBulletList, BlockOrderedList:
+			text = listPlainText(block, indent)
+		case BlockRule:
+			text = "---"
+		}
+		if strings.TrimSpace(text) != "" {
+			parts = append(parts, indent+text)
+		}
+	}
+	return parts
+}
+
+// listPlainText converte uma lista em linhas marcadas com "-" ou "N.".
+func listPlainText(block Block, indent string) string {
+	lines := make([]string, 0, len(block.Items))
+	for i, item := range block.Items {
+		marker := "- "
+		if block.Type == BlockOrderedList {
+			marker = fmt.Sprintf("%d. ", block.ListStart()+i)
+		}
+		content := blocksPlainText(item.Blocks, indent+"  ")
+		if len(content) == 0 {
+			continue
+		}
+		first := strings.TrimPrefix(content[0], indent+"  ")
+		lines = append(lines, marker+first)
+		lines = append(lines, content[1:]...)
+	}
+	return strings.Join(lines, "\n"+indent)
+}
+
+// truncateBlocks copia blocos até esgotar remaining caracteres.
+// Retorna os blocos copiados e se o limite foi atingido.
+func truncateBlocks(blocks []Block, remaining *int) ([]Block, bool) {
+	var result []Block
+	for i, block := range blocks {
+		if *remaining <= 0 {
+			return result, len(blocksPlainText(blocks[i:], "")) > 0
+		}
+
+		switch block.Type {
+		case BlockParagraph, BlockHeading:
+			inlines, cut := truncateInlines(block.Inlines, remaining)
+			block.Inlines = inlines
+			result = append(result, block)
+			if cut {
+				return result, true
+			}
+		case BlockCode:
+			text, cut := truncateText(block.Text, remaining)
+			block.Text = text
+			result = append(result, block)
+			if cut {
+				return result, true
+			}
+		case BlockQuote:
+			children, cut := truncateBlocks(block.Children, remaining)
+			block.Children = children
+			result = append(result, block)
+			if cut {
+				return result, true
+			}
+		case BlockBulletList, BlockOrderedList:
+			items := make([]ListItem, 0, len(block.Items))
+			cut := false
+			for i, item := range block.Items {
+				if *remaining <= 0 {
+					rest := Block{Type: block.Type, Items: block.Items[i:]}
+					cut = len(blocksPlainText([]Block{rest}, "")) > 0
+					break
+				}
+				var itemBlocks []Block
+				itemBlocks, cut = truncateBlocks(item.Blocks, remaining)
+				items = append(items, ListItem{Blocks: itemBlocks})
+				if cut {
+					break
+				}
+			}
+			block.Items = items
+			result = append(result, block)
+			if cut {
+				return result, true
+			}
+		default:
+			result = append(result, block)
+		}
+	}
+	return result, false
+}
+
+// truncateInlines copia trechos de texto até esgotar remaining caracteres.
+func truncateInlines(inlines []Inline, remaining *int) ([]Inline, bool) {
+	result := make([]Inline, 0, len(inlines))
+	for _, inline := range inlines {
+		if inline.Break {
+			result = append(result, inline)
+			continue
+		}
+		text, cut := truncateText(inline.Text, remaining)
+		inline.Text = text
+		result = append(result, inline)
+		if cut {
+			return result, true
+		}
+	}
+	return result, false
+}
+
+// truncateText corta o texto quando ele excede remaining caracteres.
+func truncateText(text string, remaining *int) (string, bool) {
+	length := utf8.RuneCountInString(text)
+	if length <= *remaining {
+		*remaining -= length
+		return text, false
+	}
+
+	runes := []rune(text)
+	cut := string(runes[:*remaining])
+	*remaining = 0
+	return cut, true
+}
+
+// appendTruncationSuffix adiciona as reticências ao último trecho de texto
+// dos blocos, removendo os espaços finais. Sem trecho de texto ao final (ex:
+// uma linha horizontal), adiciona um parágrafo com as reticências.
+func appendTruncationSuffix(blocks []Block) []Block {
+	if len(blocks) > 0 && appendSuffixToBlock(&blocks[len(blocks)-1]) {
+		return blocks
+	}
+	return append(blocks, Block{
+		Type: BlockParagraph, Inlines: []Inline{{Text: truncationSuffix}},
+	})
+}
+
+// appendSuffixToBlock adiciona as reticências ao último trecho de texto do
+// bloco, retornando false quando o bloco não termina em texto.
+func appendSuffixToBlock(block *Block) bool {
+	switch block.Type {
+	case BlockParagraph, BlockHeading:
+		for i := len(block.Inlines) - 1; i >= 0; i-- {
+			inline := &block.Inlines[i]
+			if inline.Break {
+				continue
+			}
+			inline.Text = strings.TrimRight(inline.Text, " ") + truncationSuffix
+			return true
+		}
+		block.Inlines = append(block.Inlines, Inline{Text: truncationSuffix})
+		return true
+	case BlockCode:
+		block.Text = strings.TrimRight(block.Text, " ") + truncationSuffix
+		return true
+	case BlockQuote:
+		block.Children = appendTruncationSuffix(block.Children)
+		return true
+	case BlockBulletList, BlockOrderedList:
+		if len(block.Items) == 0 {
+			return false
+		}
+		item := &block.Items[len(block.Items)-1]
+		item.Blocks = appendTruncationSuffix(item.Blocks)
+		return true
+	}
+	return false
+}
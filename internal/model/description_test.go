@@ -0,0 +1,162 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+// text cria um parágrafo com um único trecho de texto.
+func text(value string) Block {
+	return Block{Type: BlockParagraph, Inlines: []Inline{{Text: value}}}
+}
+
+func TestDescriptionSummary(t *testing.T) {
+	tests := []struct {
+		name     string
+		blocks   []Block
+		expected []Block
+	}{
+		{
+			name:     "primeiro bloco",
+			blocks:   []Block{text("um"), text("dois")},
+			expected: []Block{text("um")},
+		},
+		{
+			name:     "ignora blocos sem texto",
+			blocks:   []Block{text("  "), {Type: BlockBulletList}, text("dois")},
+			expected: []Block{text("dois")},
+		},
+		{
+			name: "lista inteira",
+			blocks: []Block{{Type: BlockBulletList, Items: []ListItem{
+				{Blocks: []Block{text("a")}}, {Blocks: []Block{text("b")}},
+			}}, text("depois")},
+			expected: []Block{{Type: BlockBulletList, Items: []ListItem{
+				{Blocks: []Block{text("a")}}, {Blocks: []Block{text("b")}},
+			}}},
+		},
+		{
+			name:     "descrição vazia",
+			blocks:   nil,
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		got := Description{Blocks: tt.blocks}.Summary().Blocks
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: esperado %+v, obtido %+v", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestDescriptionTruncate(t *testing.T) {
+	tests := []struct {
+		name      string
+		blocks    []Block
+		maxLength int
+		expected  string // Texto simples da descrição cortada
+		cut       bool
+	}{
+		{
+			name:      "dentro do limite",
+			blocks:    []Block{text("abc"), text("def")},
+			maxLength: 6,
+			expected:  "abc\n\ndef",
+		},
+		{
+			name:      "corta o trecho e remove espaços",
+			blocks:    []Block{text("uma frase longa")},
+			maxLength: 4,
+			expected:  "uma…",
+			cut:       true,
+		},
+		{
+			name:      "descarta os blocos seguintes",
+			blocks:    []Block{text("abc"), text("def"), text("ghi")},
+			maxLength: 5,
+			expected:  "abc\n\nde…",
+			cut:       true,
+		},
+		{
+			name:      "conta caracteres e não bytes",
+			blocks:    []Block{text("ação é")},
+			maxLength: 4,
+			expected:  "ação…",
+			cut:       true,
+		},
+		{
+			name: "corta itens de lista",
+			blocks: []Block{{Type: BlockOrderedList, Items: []ListItem{
+				{Blocks: []Block{text("primeiro")}},
+				{Blocks: []Block{text("segundo")}},
+				{Blocks: []Block{text("terceiro")}},
+			}}},
+			maxLength: 11,
+			expected:  "1. primeiro\n2. seg…",
+			cut:       true,
+		},
+		{
+			name:      "código",
+			blocks:    []Block{{Type: BlockCode, Text: "x := 1\ny := 2"}},
+			maxLength: 6,
+			expected:  "x := 1…",
+			cut:       true,
+		},
+		{
+			name:      "limite exato no fim do primeiro parágrafo",
+			blocks:    []Block{text("abcde"), text("fgh")},
+			maxLength: 5,
+			expected:  "abcde…",
+			cut:       true,
+		},
+		{
+			name:      "limite exato com espaço final",
+			blocks:    []Block{text("abcd "), text("fgh")},
+			maxLength: 5,
+			expected:  "abcd…",
+			cut:       true,
+		},
+		{
+			name:      "limite exato sem conteúdo depois",
+			blocks:    []Block{text("abcde"), text("  "), {Type: BlockBulletList}},
+			maxLength: 5,
+			expected:  "abcde",
+		},
+		{
+			name: "limite exato no fim de um item de lista",
+			blocks: []Block{{Type: BlockBulletList, Items: []ListItem{
+				{Blocks: []Block{text("um")}},
+				{Blocks: []Block{text("dois")}},
+			}}},
+			maxLength: 2,
+			expected:  "- um…",
+			cut:       true,
+		},
+		{
+			name: "limite exato no fim de uma citação",
+			blocks: []Block{
+				{Type: BlockQuote, Children: []Block{text("citado")}},
+				text("depois"),
+			},
+			maxLength: 6,
+			expected:  "citado…",
+			cut:       true,
+		},
+		{
+			name:      "limite exato após uma linha horizontal",
+			blocks:    []Block{text("abc"), {Type: BlockRule}, text("depois")},
+			maxLength: 3,
+			expected:  "abc…",
+			cut:       true,
+		},
+	}
+	for _, tt := range tests {
+		truncated, cut := Description{Blocks: tt.blocks}.Truncate(tt.maxLength)
+		if got := truncated.PlainText(); got != tt.expected || cut != tt.cut {
+			t.Errorf(
+				"%s: esperado %q (corte %t), obtido %q (corte %t)",
+				tt.name, tt.expected, tt.cut, got, cut,
+			)
+		}
+	}
+}
@@ -1,25 +1,72 @@
 package model
 
+import (
+	"strings"
+	"time"
+)
+
 // Issue representa uma issue do Jira com os dados necessários para o relatório.
 type Issue struct {
-	Key         string `json:"key"`
-	Summary     string `json:"summary"`
-	Description string `json:"description"`
-	Date        string `json:"date"`
-	URL         string `json:"url"`
+	Key         string      `json:"key"`
+	Summary     string      `json:"summary"`
+	Description Description `json:"description"`
+	URL         string      `json:"url"`
+	Created     time.Time   `json:"created,omitzero"`     // Criação da issue
+	Assigned    time.Time   `json:"assigned,omitzero"`    // Atribuição ao responsável atual
+	Started     time.Time   `json:"started,omitzero"`     // Entrada em um status de início
+	Resolved    time.Time   `json:"resolved,omitzero"`    // Resolução da issue
+	Status      string      `json:"status,omitempty"`     // Status atual no Jira
+	TimeSpent   []DailyTime `json:"time_spent,omitempty"` // Horas registradas por dia
 }
 
 // NewIssue cria uma nova instância de Issue.
-func NewIssue(key, summary, description, date, url string) *Issue {
+func NewIssue(
+	key, summary string, description Description, created time.Time,
+	url string,
+) *Issue {
 	return &Issue{
 		Key:         key,
 		Summary:     summary,
 		Description: description,
-		Date:        date,
+		Created:     created,
 		URL:         url,
 	}
 }
 
+// Date retorna a data relevante da issue para o relatório: o início do
+// trabalho, a atribuição ao responsável ou, na falta delas, a criação.
+// Retorna o instante zero quando nenhuma data é conhecida.
+func (i Issue) Date() time.Time {
+	switch {
+	case !i.Started.IsZero():
+		return i.Started
+	case !i.Assigned.IsZero():
+		return i.Assigned
+	default:
+		return i.Created
+	}
+}
+
+// Project retorna a chave do projeto da issue (ex: PROJ em PROJ-123).
+func (i Issue) Project() string {
+	project, _, _ := strings.Cut(i.Key, "-")
+	return project
+}
+
+// TimeSpentSeconds retorna o total de segundos registrados na issue.
+func (i Issue) TimeSpentSeconds() int {
+	total := 0
+	for _, day := range i.TimeSpent {
+		total += day.Seconds
+	}
+	return total
+}
+
+// Hours retorna o total de horas registradas na issue.
+func (i Issue) Hours() float64 {
+	return float64(i.TimeSpentSeconds()) / secondsPerHour
+}
+
 // IssueCollection representa uma coleção de issues.
 type IssueCollection struct {
 	Items []Issue
@@ -0,0 +1,102 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// PeriodKind identifica como o período do relatório foi informado.
+type PeriodKind string
+
+const (
+	PeriodMonth   PeriodKind = "month"   // Mês/ano (MM/YYYY)
+	PeriodWeek    PeriodKind = "week"    // Semana ISO (YYYY-Www)
+	PeriodQuarter PeriodKind = "quarter" // Trimestre (Qn/YYYY)
+	PeriodRange   PeriodKind = "range"   // Intervalo livre de datas
+	PeriodSprint  PeriodKind = "sprint"  // Datas de uma sprint do Jira
+)
+
+// Period representa o intervalo de datas coberto pelo relatório.
+type Period struct {
+	Start time.Time  // Primeiro dia do período
+	End   time.Time  // Último dia do período (inclusivo)
+	Kind  PeriodKind // Forma como o período foi informado
+	Name  string     // Identificação curta (ex: 2025-W14, Q1/2025)
+}
+
+// NewMonthPeriod cria o período correspondente a um mês/ano.
+func NewMonthPeriod(month, year int) Period {
+	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	return Period{
+		Start: start,
+		End:   start.AddDate(0, 1, -1),
+		Kind:  PeriodMonth,
+		Name:  start.Format("01/2006"),
+	}
+}
+
+// NewWeekPeriod cria o período da semana ISO (segunda a domingo).
+func NewWeekPeriod(week, year int) Period {
+	// 4 de janeiro sempre pertence à primeira semana ISO do ano
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	offset := (int(jan4.Weekday()) + 6) % 7
+	start := jan4.AddDate(0, 0, -offset+(week-1)*7)
+	return Period{
+		Start: start,
+		End:   start.AddDate(0, 0, 6),
+		Kind:  PeriodWeek,
+		Name:  fmt.Sprintf("%d-W%02d", year, week),
+	}
+}
+
+// NewQuarterPeriod cria o período de um trimestre (1 a 4).
+func NewQuarterPeriod(quarter, year int) Period {
+	start := time.Date(year, time.Month((quarter-1)*3+1), 1, 0, 0, 0, 0, time.UTC)
+	return Period{
+		Start: start,
+		End:   start.AddDate(0, 3, -1),
+		Kind:  PeriodQuarter,
+		Name:  fmt.Sprintf("Q%d/%d", quarter, year),
+	}
+}
+
+// NewRangePeriod cria um período livre entre as datas informadas.
+func NewRangePeriod(start, end time.Time) Period {
+	return Period{Start: start, End: end, Kind: PeriodRange}
+}
+
+// IsMonth verifica se o período corresponde a um mês de competência.
+func (p Period) IsMonth() bool {
+	return p.Kind == PeriodMonth
+}
+
+// Label retorna o período para exibição no relatório.
+func (p Period) Label() string {
+	if p.IsMonth() {
+		return p.Name
+	}
+
+	dates := p.Start.Format("02/01/2006") + " a " + p.End.Format("02/01/2006")
+	if p.Name == "" {
+		return dates
+	}
+	return fmt.Sprintf("%s (%s)", p.Name, dates)
+}
+
+// FileLabel retorna o período em um formato seguro para nomes de arquivo.
+func (p Period) FileLabel() string {
+	dates := p.Start.Format("2006-01-02") + "_" + p.End.Format("2006-01-02")
+
+	switch p.Kind {
+	case PeriodMonth:
+		return p.Start.Format("01_2006")
+	case PeriodWeek:
+		return p.Name
+	case PeriodQuarter:
+		return fmt.Sprintf("Q%d_%d", (int(p.Start.Month())-1)/3+1, p.Start.Year())
+	case PeriodSprint:
+		return "sprint_" + dates
+	default:
+		return dates
+	}
+}
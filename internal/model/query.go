@@ -0,0 +1,50 @@
+package model
+
+import "strings"
+
+// DefaultProfileName é o nome do perfil de consulta usado por padrão.
+const DefaultProfileName = "default"
+
+// Placeholders aceitos no template JQL dos perfis de consulta.
+const (
+	PlaceholderStart   = "{{start}}"   // Primeiro minuto do período (YYYY-MM-DD HH:mm)
+	PlaceholderEnd     = "{{end}}"     // Último minuto do período (YYYY-MM-DD HH:mm)
+	PlaceholderStarted = "{{started}}" // Condição de mudança para os status de início
+)
+
+// QueryProfile define uma consulta nomeada para a busca de issues no Jira.
+type QueryProfile struct {
+	Name string
+	// BaseJQL é o template da condição de período, com os placeholders
+	// {{start}}, {{end}} e {{started}}.
+	BaseJQL string
+	// StartedStatuses são os status que indicam o início do trabalho.
+	StartedStatuses []string
+	// QAFields são os campos (user picker) que identificam o QA da issue.
+	QAFields []string
+	// Projects e Labels são filtros adicionais (opcionais).
+	Projects []string
+	Labels   []string
+}
+
+// NewDefaultQueryProfile cria o perfil padrão, compatível com a consulta
+// original da aplicação.
+func NewDefaultQueryProfile() QueryProfile {
+	return QueryProfile{
+		Name: DefaultProfileName,
+		BaseJQL: PlaceholderStarted + " OR created >= '" + PlaceholderStart +
+			"' AND created <= '" + PlaceholderEnd + "'",
+		StartedStatuses: []string{"In Progress"},
+		QAFields:        []string{"QA[User Picker (single user)]"},
+	}
+}
+
+// IsStartedStatus verifica se o status indica o início do trabalho.
+func (p QueryProfile) IsStartedStatus(status string) bool {
+	for _, started := range p.StartedStatuses {
+		if strings.EqualFold(started, status) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,53 +1,89 @@
 package model
 
+import (
+	"io"
+	"strings"
+)
+
 // ReportData agrega todos os dados necessários para gerar um relatório.
 type ReportData struct {
 	User       User
 	Jira       IssueCollection
-	DateWorked string
+	DateWorked string        // Período formatado para exibição
+	Period     Period        // Período coberto pelo relatório
+	Sprint     *Sprint       // Sprint do relatório (nil fora do modo sprint)
+	Hours      *HoursSummary // Horas registradas (nil quando não solicitado)
 }
 
 // NewReportData cria uma nova instância de ReportData.
 func NewReportData(
-	user User, issues IssueCollection, dateWorked string,
+	user User, issues IssueCollection, period Period,
 ) *ReportData {
 	return &ReportData{
 		User:       user,
 		Jira:       issues,
-		DateWorked: dateWorked,
+		DateWorked: period.Label(),
+		Period:     period,
 	}
 }
 
-// ReportFormat representa os formatos de saída suportados.
+// ReportFormat identifica um formato de saída. Os formatos disponíveis são
+// os dos geradores registrados em view.Registry.
 type ReportFormat string
 
 const (
-	FormatHTML ReportFormat = "html"
-	FormatDOCX ReportFormat = "docx"
+	FormatHTML     ReportFormat = "html"
+	FormatDOCX     ReportFormat = "docx"
+	FormatPDF      ReportFormat = "pdf"
+	FormatMarkdown ReportFormat = "md"
+	FormatCSV      ReportFormat = "csv"
+	FormatXLSX     ReportFormat = "xlsx"
+	FormatJSON     ReportFormat = "json"
 )
 
-// IsValid verifica se o formato é válido.
-func (f ReportFormat) IsValid() bool {
-	return f == FormatHTML || f == FormatDOCX
-}
-
 // String retorna a representação string do formato.
 func (f ReportFormat) String() string {
 	return string(f)
 }
 
-// Extension retorna a extensão de arquivo para o formato.
-func (f ReportFormat) Extension() string {
-	return string(f)
+// ParseFormats converte uma lista de formatos separados por vírgula
+// (ex: "html,docx,pdf"), ignorando espaços e repetições.
+func ParseFormats(value string) []ReportFormat {
+	var formats []ReportFormat
+	seen := map[ReportFormat]bool{}
+	for _, part := range strings.Split(value, ",") {
+		format := ReportFormat(strings.ToLower(strings.TrimSpace(part)))
+		if format == "" || seen[format] {
+			continue
+		}
+		seen[format] = true
+		formats = append(formats, format)
+	}
+	return formats
 }
 
 // ReportOptions contém as opções para geração de relatório.
 type ReportOptions struct {
 	Name      string
 	Path      string
-	Format    ReportFormat
-	Date      string // Mês/ano no formato MM/YYYY (opcional, padrão: mês anterior)
-	IncludeQA bool   // Incluir cards onde o usuário é QA
+	Formats   []ReportFormat // Formatos gerados a partir da mesma busca
+	Date      string         // Período (MM/YYYY, YYYY-Www, Qn/YYYY ou "last N days")
+	From      string         // Início de um intervalo livre (YYYY-MM-DD)
+	To        string         // Fim de um intervalo livre (YYYY-MM-DD)
+	Board     string         // Board da sprint (id ou nome)
+	Sprint    string         // Sprint do relatório (id, nome ou "last")
+	IncludeQA bool           // Incluir cards onde o usuário é QA
+	Hours     bool           // Incluir horas registradas (worklogs) no relatório
+	Profile   string         // Perfil de consulta JQL (opcional, padrão: configurado)
+	JQL       string         // JQL avulsa que substitui o perfil (opcional)
+
+	// TemplatePath é o template HTML do relatório, já resolvido a partir da
+	// flag --template ou da configuração (vazio usa o template embutido).
+	TemplatePath string
+
+	// Output recebe o relatório em vez de um arquivo em Path (ex: a saída
+	// padrão com --stdout), com um único formato. Nil grava em arquivo.
+	Output io.Writer
 }
 
 // NewReportOptions cria opções com valores padrão.
@@ -55,7 +91,7 @@ func NewReportOptions() *ReportOptions {
 	return &ReportOptions{
 		Name:      "",
 		Path:      "reports",
-		Format:    FormatHTML,
+		Formats:   []ReportFormat{FormatHTML},
 		Date:      "", // Vazio significa mês anterior
 		IncludeQA: false,
 	}
@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// SprintLast seleciona a última sprint encerrada do board.
+const SprintLast = "last"
+
+// Sprint representa uma sprint do Jira Agile usada como período do relatório.
+type Sprint struct {
+	ID      int
+	BoardID int
+	Name    string
+	Goal    string
+	State   string    // future, active ou closed
+	Start   time.Time // Início da sprint
+	End     time.Time // Fim da sprint (data de conclusão, quando encerrada)
+}
+
+// Period retorna o período coberto pela sprint.
+func (s Sprint) Period() Period {
+	return Period{
+		Start: dateOnly(s.Start),
+		End:   dateOnly(s.End),
+		Kind:  PeriodSprint,
+		Name:  s.Name,
+	}
+}
+
+// dateOnly descarta o horário, mantendo o dia do calendário no fuso da
+// data (representado à meia-noite UTC, como as demais datas de período).
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
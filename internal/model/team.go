@@ -0,0 +1,61 @@
+package model
+
+// TeamMember representa um membro da equipe no modo de relatório em equipe.
+type TeamMember struct {
+	ID        string // Identificador do membro na configuração (ex: joao)
+	AccountID string // accountId do Jira (resolvido pelo e-mail, se vazio)
+	Email     string // E-mail do Jira, usado quando não há accountId
+	User      User   // Dados da empresa e do responsável legal
+}
+
+// MemberReport resume o relatório gerado para um membro da equipe.
+type MemberReport struct {
+	Member TeamMember
+	Issues int           // Quantidade de issues no relatório
+	Hours  *HoursSummary // Horas registradas (nil quando não solicitado)
+	Files  []string      // Caminhos dos relatórios gerados (um por formato)
+	Error  string        // Motivo da falha (vazio quando gerado com sucesso)
+}
+
+// Failed verifica se a geração do relatório do membro falhou.
+func (m MemberReport) Failed() bool {
+	return m.Error != ""
+}
+
+// TeamSummary consolida os relatórios gerados para a equipe.
+type TeamSummary struct {
+	DateWorked string  // Período formatado para exibição
+	Period     Period  // Período coberto pelos relatórios
+	Sprint     *Sprint // Sprint dos relatórios (nil fora do modo sprint)
+	Members    []MemberReport
+}
+
+// TotalIssues retorna a quantidade de issues de todos os membros.
+func (t *TeamSummary) TotalIssues() int {
+	total := 0
+	for _, member := range t.Members {
+		total += member.Issues
+	}
+	return total
+}
+
+// HasHours verifica se os relatórios incluem horas registradas.
+func (t *TeamSummary) HasHours() bool {
+	for _, member := range t.Members {
+		if member.Hours != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// TotalHours retorna o total de horas registradas pela equipe.
+func (t *TeamSummary) TotalHours() float64 {
+	total := 0.0
+	for _, member := range t.Members {
+		if member.Hours != nil {
+			total += member.Hours.TotalHours()
+		}
+	}
+	return total
+}
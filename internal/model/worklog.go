@@ -0,0 +1,71 @@
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+// secondsPerHour é usado para converter segundos registrados em horas.
+const secondsPerHour = 3600.0
+
+// DailyTime representa o tempo registrado em um dia.
+type DailyTime struct {
+	Date    time.Time `json:"date"` // Dia do registro (meia-noite)
+	Seconds int       `json:"seconds"`
+}
+
+// Hours retorna o tempo registrado no dia em horas.
+func (d DailyTime) Hours() float64 {
+	return float64(d.Seconds) / secondsPerHour
+}
+
+// AddTimeSpent acumula o tempo registrado no dia, mantendo a lista ordenada.
+func AddTimeSpent(days []DailyTime, date time.Time, seconds int) []DailyTime {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	for i := range days {
+		if days[i].Date.Equal(day) {
+			days[i].Seconds += seconds
+			return days
+		}
+	}
+
+	days = append(days, DailyTime{Date: day, Seconds: seconds})
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Date.Before(days[j].Date)
+	})
+	return days
+}
+
+// HoursSummary consolida as horas registradas no período do relatório.
+type HoursSummary struct {
+	Days         []DailyTime // Total registrado por dia, em ordem cronológica
+	TotalSeconds int
+	HourlyRate   float64 // Valor da hora (0 quando não configurado)
+}
+
+// NewHoursSummary consolida as horas registradas nas issues da coleção.
+func NewHoursSummary(issues IssueCollection, hourlyRate float64) *HoursSummary {
+	summary := &HoursSummary{HourlyRate: hourlyRate}
+	for _, issue := range issues.Items {
+		for _, day := range issue.TimeSpent {
+			summary.Days = AddTimeSpent(summary.Days, day.Date, day.Seconds)
+			summary.TotalSeconds += day.Seconds
+		}
+	}
+	return summary
+}
+
+// TotalHours retorna o total de horas registradas no período.
+func (h *HoursSummary) TotalHours() float64 {
+	return float64(h.TotalSeconds) / secondsPerHour
+}
+
+// HasRate verifica se há valor da hora para o cálculo da fatura.
+func (h *HoursSummary) HasRate() bool {
+	return h.HourlyRate > 0
+}
+
+// Amount retorna o valor a faturar (total de horas x valor da hora).
+func (h *HoursSummary) Amount() float64 {
+	return h.TotalHours() * h.HourlyRate
+}
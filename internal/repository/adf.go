@@ -0,0 +1,283 @@
+package repository
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+)
+
+// adfDateFormat é o formato usado para exibir nós "date" do ADF.
+const adfDateFormat = "02/01/2006"
+
+// parseADF percorre a árvore do Atlassian Document Format e a converte
+// para a descrição estruturada do domínio.
+func parseADF(node *models.CommentNodeScheme) model.Description {
+	if node == nil {
+		return model.Description{}
+	}
+	return model.Description{Blocks: parseADFBlocks(node.Content)}
+}
+
+// parseADFBlocks converte nós de bloco do ADF.
+// Nós de bloco desconhecidos têm seu conteúdo convertido recursivamente.
+func parseADFBlocks(nodes []*models.CommentNodeScheme) []model.Block {
+	var blocks []model.Block
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+
+		switch node.Type {
+		case "paragraph":
+			blocks = append(blocks, model.Block{
+				Type:    model.BlockParagraph,
+				Inlines: parseADFInlines(node.Content),
+			})
+		case "heading":
+			blocks = append(blocks, model.Block{
+				Type:    model.BlockHeading,
+				Level:   adfIntAttr(node.Attrs, "level", 1),
+				Inlines: parseADFInlines(node.Content),
+			})
+		case "bulletList", "taskList", "decisionList":
+			blocks = append(blocks, model.Block{
+				Type:  model.BlockBulletList,
+				Items: parseADFListItems(node.Content),
+			})
+		case "orderedList":
+			blocks = append(blocks, model.Block{
+				Type:  model.BlockOrderedList,
+				Start: adfIntAttr(node.Attrs, "order", 1),
+				Items: parseADFListItems(node.Content),
+			})
+		case "codeBlock":
+			blocks = append(blocks, model.Block{
+				Type:     model.BlockCode,
+				Language: adfStringAttr(node.Attrs, "language"),
+				Text:     model.InlinesPlainText(parseADFInlines(node.Content)),
+			})
+		case "blockquote", "panel":
+			blocks = append(blocks, model.Block{
+				Type:     model.BlockQuote,
+				Children: parseADFBlocks(node.Content),
+			})
+		case "rule":
+			blocks = append(blocks, model.Block{Type: model.BlockRule})
+		case "expand", "nestedExpand":
+			if title := adfStringAttr(node.Attrs, "title"); title != "" {
+				blocks = append(blocks, model.Block{
+					Type:    model.BlockParagraph,
+					Inlines: []model.Inline{{Text: title, Bold: true}},
+				})
+			}
+			blocks = append(blocks, parseADFBlocks(node.Content)...)
+		case "table":
+			blocks = append(blocks, parseADFTable(node)...)
+		case "mediaSingle", "mediaGroup", "media":
+			// Anexos não têm representação textual no relatório
+		default:
+			if len(node.Content) > 0 {
+				blocks = append(blocks, parseADFBlocks(node.Content)...)
+			} else if inlines := parseADFInlines([]*models.CommentNodeScheme{node}); len(inlines) > 0 {
+				blocks = append(blocks, model.Block{
+					Type: model.BlockParagraph, Inlines: inlines,
+				})
+			}
+		}
+	}
+	return blocks
+}
+
+// parseADFListItems converte os itens de uma lista.
+// Itens de tarefa recebem o prefixo [ ] ou [x] conforme o estado.
+func parseADFListItems(nodes []*models.CommentNodeScheme) []model.ListItem {
+	items := make([]model.ListItem, 0, len(nodes))
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+
+		switch node.Type {
+		case "taskItem", "decisionItem":
+			marker := "[ ] "
+			if adfStringAttr(node.Attrs, "state") == "DONE" ||
+				adfStringAttr(node.Attrs, "state") == "DECIDED" {
+				marker = "[x] "
+			}
+			inlines := append(
+				[]model.Inline{{Text: marker}}, parseADFInlines(node.Content)...,
+			)
+			items = append(items, model.ListItem{Blocks: []model.Block{{
+				Type: model.BlockParagraph, Inlines: inlines,
+			}}})
+		default:
+			items = append(items, model.ListItem{
+				Blocks: parseADFBlocks(node.Content),
+			})
+		}
+	}
+	return items
+}
+
+// parseADFTable converte uma tabela em parágrafos, um por linha,
+// com as células separadas por " | " e o texto de cada célula em uma linha.
+func parseADFTable(node *models.CommentNodeScheme) []model.Block {
+	var blocks []model.Block
+	for _, row := range node.Content {
+		if row == nil {
+			continue
+		}
+
+		var inlines []model.Inline
+		for i, cell := range row.Content {
+			if cell == nil {
+				continue
+			}
+			if i > 0 {
+				inlines = append(inlines, model.Inline{Text: " | "})
+			}
+			text := model.Description{Blocks: parseADFBlocks(cell.Content)}
+			inlines = append(inlines, model.Inline{
+				Text: strings.Join(strings.Fields(text.PlainText()), " "),
+				Bold: cell.Type == "tableHeader",
+			})
+		}
+		blocks = append(blocks, model.Block{
+			Type: model.BlockParagraph, Inlines: inlines,
+		})
+	}
+	return blocks
+}
+
+// parseADFInlines converte nós inline do ADF (texto, menções, links etc.).
+func parseADFInlines(nodes []*models.CommentNodeScheme) []model.Inline {
+	var inlines []model.Inline
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+
+		switch node.Type {
+		case "text":
+			inlines = append(inlines, applyADFMarks(
+				model.Inline{Text: node.Text}, node.Marks,
+			))
+		case "hardBreak":
+			inlines = append(inlines, model.Inline{Break: true})
+		case "mention":
+			text := adfStringAttr(node.Attrs, "text")
+			if text == "" {
+				text = "@" + adfStringAttr(node.Attrs, "id")
+			}
+			if !strings.HasPrefix(text, "@") {
+				text = "@" + text
+			}
+			inlines = append(inlines, model.Inline{Text: text, Mention: true})
+		case "emoji":
+			text := adfStringAttr(node.Attrs, "text")
+			if text == "" {
+				text = adfStringAttr(node.Attrs, "shortName")
+			}
+			inlines = append(inlines, model.Inline{Text: text})
+		case "inlineCard", "blockCard", "embedCard":
+			url := adfStringAttr(node.Attrs, "url")
+			if url != "" {
+				inlines = append(inlines, model.Inline{Text: url, Link: url})
+			}
+		case "status":
+			inlines = append(inlines, model.Inline{
+				Text: adfStringAttr(node.Attrs, "text"), Bold: true,
+			})
+		case "date":
+			inlines = append(inlines, model.Inline{
+				Text: adfDateAttr(node.Attrs, "timestamp"),
+			})
+		default:
+			if node.Text != "" {
+				inlines = append(inlines, model.Inline{Text: node.Text})
+			}
+			inlines = append(inlines, parseADFInlines(node.Content)...)
+		}
+	}
+	return inlines
+}
+
+// applyADFMarks aplica as marcações do ADF (negrito, link etc.) ao trecho.
+func applyADFMarks(
+	inline model.Inline, marks []*models.MarkScheme,
+) model.Inline {
+	for _, mark := range marks {
+		if mark == nil {
+			continue
+		}
+
+		switch mark.Type {
+		case "strong":
+			inline.Bold = true
+		case "em":
+			inline.Italic = true
+		case "underline":
+			inline.Underline = true
+		case "strike":
+			inline.Strike = true
+		case "code":
+			inline.Code = true
+		case "link":
+			inline.Link = adfStringAttr(mark.Attrs, "href")
+		}
+	}
+	return inline
+}
+
+// adfStringAttr lê um atributo textual de um nó do ADF.
+func adfStringAttr(attrs map[string]interface{}, key string) string {
+	value, ok := attrs[key]
+	if !ok || value == nil {
+		return ""
+	}
+	if text, ok := value.(string); ok {
+		return text
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// adfIntAttr lê um atributo numérico de um nó do ADF.
+func adfIntAttr(
+	attrs map[string]interface{}, key string, defaultValue int,
+) int {
+	switch value := attrs[key].(type) {
+	case float64:
+		return int(value)
+	case int:
+		return value
+	case string:
+		if parsed, err := strconv.Atoi(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// adfDateAttr converte um timestamp em milissegundos do ADF em data.
+// O Jira grava o nó "date" como a meia-noite UTC do dia escolhido, por isso
+// a data é lida em UTC: em outro fuso ela cairia no dia anterior ou seguinte.
+func adfDateAttr(attrs map[string]interface{}, key string) string {
+	var millis int64
+	switch value := attrs[key].(type) {
+	case float64:
+		millis = int64(value)
+	case string:
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return value
+		}
+		millis = parsed
+	default:
+		return ""
+	}
+	return time.UnixMilli(millis).UTC().Format(adfDateFormat)
+}
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+)
+
+// paragraph cria um bloco de parágrafo com os trechos informados.
+func paragraph(inlines ...model.Inline) model.Block {
+	return model.Block{Type: model.BlockParagraph, Inlines: inlines}
+}
+
+func TestParseADF(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string // Conteúdo do documento ADF
+		expected []model.Block
+	}{
+		{
+			name: "lista com item aninhado",
+			content: `[{"type": "bulletList", "content": [
+				{"type": "listItem", "content": [
+					{"type": "paragraph", "content": [{"type": "text", "text": "um"}]},
+					{"type": "orderedList", "attrs": {"order": 3}, "content": [
+						{"type": "listItem", "content": [
+							{"type": "paragraph", "content": [{"type": "text", "text": "dois"}]}
+						]}
+					]}
+				]}
+			]}]`,
+			expected: []model.Block{{
+				Type: model.BlockBulletList,
+				Items: []model.ListItem{{Blocks: []model.Block{
+					paragraph(model.Inline{Text: "um"}),
+					{
+						Type:  model.BlockOrderedList,
+						Start: 3,
+						Items: []model.ListItem{{Blocks: []model.Block{
+							paragraph(model.Inline{Text: "dois"}),
+						}}},
+					},
+				}}},
+			}},
+		},
+		{
+			name: "bloco de código",
+			content: `[{"type": "codeBlock", "attrs": {"language": "go"}, "content": [
+				{"type": "text", "text": "x := 1\n"},
+				{"type": "text", "text": "y := 2"}
+			]}]`,
+			expected: []model.Block{{
+				Type: model.BlockCode, Language: "go", Text: "x := 1\ny := 2",
+			}},
+		},
+		{
+			name: "menções com e sem texto",
+			content: `[{"type": "paragraph", "content": [
+				{"type": "mention", "attrs": {"id": "abc", "text": "@João"}},
+				{"type": "text", "text": " e "},
+				{"type": "mention", "attrs": {"id": "def", "text": "Maria"}},
+				{"type": "text", "text": " e "},
+				{"type": "mention", "attrs": {"id": "ghi"}}
+			]}]`,
+			expected: []model.Block{paragraph(
+				model.Inline{Text: "@João", Mention: true},
+				model.Inline{Text: " e "},
+				model.Inline{Text: "@Maria", Mention: true},
+				model.Inline{Text: " e "},
+				model.Inline{Text: "@ghi", Mention: true},
+			)},
+		},
+		{
+			name: "tabela com cabeçalho",
+			content: `[{"type": "table", "content": [
+				{"type": "tableRow", "content": [
+					{"type": "tableHeader", "content": [
+						{"type": "paragraph", "content": [{"type": "text", "text": "Nome"}]}
+					]},
+					{"type": "tableHeader", "content": [
+						{"type": "paragraph", "content": [{"type": "text", "text": "Valor"}]}
+					]}
+				]},
+				{"type": "tableRow", "content": [
+					{"type": "tableCell", "content": [
+						{"type": "paragraph", "content": [{"type": "text", "text": "a"}]}
+					]},
+					{"type": "tableCell", "content": [
+						{"type": "paragraph", "content": [{"type": "text", "text": "linha 1"}]},
+						{"type": "paragraph", "content": [{"type": "text", "text": "linha 2"}]}
+					]}
+				]}
+			]}]`,
+			expected: []model.Block{
+				paragraph(
+					model.Inline{Text: "Nome", Bold: true},
+					model.Inline{Text: " | "},
+					model.Inline{Text: "Valor", Bold: true},
+				),
+				paragraph(
+					model.Inline{Text: "a"},
+					model.Inline{Text: " | "},
+					model.Inline{Text: "linha 1 linha 2"},
+				),
+			},
+		},
+		{
+			name: "itens de tarefa",
+			content: `[{"type": "taskList", "content": [
+				{"type": "taskItem", "attrs": {"state": "DONE"}, "content": [
+					{"type": "text", "text": "feito"}
+				]},
+				{"type": "taskItem", "attrs": {"state": "TODO"}, "content": [
+					{"type": "text", "text": "pendente"}
+				]}
+			]}]`,
+			expected: []model.Block{{
+				Type: model.BlockBulletList,
+				Items: []model.ListItem{
+					{Blocks: []model.Block{paragraph(
+						model.Inline{Text: "[x] "}, model.Inline{Text: "feito"},
+					)}},
+					{Blocks: []model.Block{paragraph(
+						model.Inline{Text: "[ ] "}, model.Inline{Text: "pendente"},
+					)}},
+				},
+			}},
+		},
+		{
+			// 1736121600000 é 06/01/2025 00:00 UTC, ainda 05/01 em fusos
+			// a oeste de Greenwich
+			name: "datas em número e texto",
+			content: `[{"type": "paragraph", "content": [
+				{"type": "date", "attrs": {"timestamp": 1736121600000}},
+				{"type": "text", "text": " a "},
+				{"type": "date", "attrs": {"timestamp": "1738281600000"}}
+			]}]`,
+			expected: []model.Block{paragraph(
+				model.Inline{Text: "06/01/2025"},
+				model.Inline{Text: " a "},
+				model.Inline{Text: "31/01/2025"},
+			)},
+		},
+		{
+			name: "marcações e link",
+			content: `[{"type": "paragraph", "content": [
+				{"type": "text", "text": "doc", "marks": [
+					{"type": "strong"},
+					{"type": "link", "attrs": {"href": "https://example.com"}}
+				]},
+				{"type": "hardBreak"},
+				{"type": "text", "text": "fim", "marks": [{"type": "code"}]}
+			]}]`,
+			expected: []model.Block{paragraph(
+				model.Inline{Text: "doc", Bold: true, Link: "https://example.com"},
+				model.Inline{Break: true},
+				model.Inline{Text: "fim", Code: true},
+			)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc models.CommentNodeScheme
+			document := `{"type": "doc", "version": 1, "content": ` + tt.content + `}`
+			if err := json.Unmarshal([]byte(document), &doc); err != nil {
+				t.Fatalf("documento ADF inválido: %v", err)
+			}
+
+			got := parseADF(&doc).Blocks
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("blocos inesperados\nesperado: %+v\nobtido:   %+v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/alan-gomes1/jira-reporter/internal/config"
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/v2/service/common"
+)
+
+// jiraAPI reúne as chamadas à API REST do Jira usadas pelo repositório. O
+// Jira Cloud usa a API v3 e o Jira Server/Data Center a v2, que diferem na
+// paginação da busca, no formato da descrição (ADF ou wiki markup) e na
+// identificação dos usuários (accountId ou nome).
+type jiraAPI interface {
+	// search busca uma página de issues. O cursor é vazio na primeira
+	// página; a página retorna o cursor da próxima, vazio na última.
+	search(
+		ctx context.Context, jql string, fields, expand []string,
+		pageSize int, cursor string,
+	) (*issuePage, *models.ResponseScheme, error)
+	// worklogs busca uma página dos worklogs da issue iniciados a partir
+	// de since.
+	worklogs(
+		ctx context.Context, issueKey string, startAt, pageSize int,
+		since time.Time,
+	) (*worklogPage, *models.ResponseScheme, error)
+	// myself retorna o usuário autenticado.
+	myself(ctx context.Context) (*models.UserScheme, *models.ResponseScheme, error)
+	// searchUsers busca usuários pelo e-mail.
+	searchUsers(
+		ctx context.Context, email string, limit int,
+	) ([]*models.UserScheme, *models.ResponseScheme, error)
+	// permissions informa, para cada permissão (ex: BROWSE_PROJECTS), se o
+	// usuário autenticado a tem.
+	permissions(
+		ctx context.Context, keys []string,
+	) (map[string]bool, *models.ResponseScheme, error)
+	// fields retorna os campos das issues, incluindo os personalizados.
+	fields(ctx context.Context) ([]*models.IssueFieldScheme, *models.ResponseScheme, error)
+}
+
+// restClient é a parte comum dos clientes das APIs v2 e v3 usada nas
+// chamadas sem método próprio no go-atlassian.
+type restClient interface {
+	NewRequest(
+		ctx context.Context, method, urlStr, contentType string, body interface{},
+	) (*http.Request, error)
+	Call(request *http.Request, structure interface{}) (*models.ResponseScheme, error)
+}
+
+// myPermissions consulta a API mypermissions, comum ao Cloud e ao
+// Server/Data Center, com as permissões informadas.
+func myPermissions(
+	ctx context.Context, client restClient, version string, keys []string,
+) (map[string]bool, *models.ResponseScheme, error) {
+	params := url.Values{}
+	params.Set("permissions", strings.Join(keys, ","))
+
+	request, err := client.NewRequest(
+		ctx, http.MethodGet,
+		fmt.Sprintf("rest/api/%s/mypermissions?%s", version, params.Encode()),
+		"", nil,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result struct {
+		Permissions map[string]struct {
+			HavePermission bool `json:"havePermission"`
+		} `json:"permissions"`
+	}
+	response, err := client.Call(request, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	granted := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		granted[key] = result.Permissions[key].HavePermission
+	}
+	return granted, response, nil
+}
+
+// issuePage é uma página da busca de issues.
+type issuePage struct {
+	issues []*apiIssue
+	next   string // Cursor da próxima página (vazio = última)
+}
+
+// apiIssue é uma issue retornada pela API com a descrição já convertida
+// para o modelo de domínio. Fields.Description não é usado.
+type apiIssue struct {
+	*models.IssueScheme
+	description model.Description
+}
+
+// worklogPage é uma página dos worklogs de uma issue.
+type worklogPage struct {
+	worklogs []*apiWorklog
+	total    int
+}
+
+// apiWorklog é um worklog retornado pela API.
+type apiWorklog struct {
+	author  *models.UserDetailScheme
+	started string
+	seconds int
+}
+
+// newJiraAPI cria o cliente da API REST de acordo com o tipo de instalação
+// do Jira, aplicando a autenticação informada.
+func newJiraAPI(
+	deployment string, httpClient *http.Client, site string,
+	auth func(common.Authentication),
+) (jiraAPI, error) {
+	if deployment == config.DeploymentServer {
+		return newServerAPI(httpClient, site, auth)
+	}
+	return newCloudAPI(httpClient, site, auth)
+}
+
+// sameUser verifica se o identificador é um dos identificadores do usuário:
+// o accountId no Cloud ou o nome ou a chave no Server/Data Center.
+func sameUser(id string, candidates ...string) bool {
+	if id == "" {
+		return false
+	}
+	for _, candidate := range candidates {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+// userID retorna o identificador do usuário: o accountId no Cloud ou o nome
+// no Server/Data Center, aceito na JQL.
+func userID(user *models.UserScheme) string {
+	if user.AccountID != "" {
+		return user.AccountID
+	}
+	return user.Name
+}
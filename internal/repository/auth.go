@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/alan-gomes1/jira-reporter/internal/config"
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/oauth2"
+	"github.com/ctreminiom/go-atlassian/v2/service/common"
+)
+
+// oauthGateway é o endereço da API do Jira Cloud para tokens OAuth, seguido
+// do cloudId do site.
+const oauthGateway = "https://api.atlassian.com/ex/jira/"
+
+// oauthScopes são os escopos pedidos na autorização OAuth: leitura das
+// issues, dos usuários, dos boards e das sprints. O offline_access, que
+// fornece o refresh token, é incluído pelo serviço OAuth.
+var oauthScopes = []string{
+	"read:jira-work",
+	"read:jira-user",
+	"read:board-scope:jira-software",
+	"read:sprint:jira-software",
+}
+
+// connection é o endereço da API e a autenticação aplicada aos clientes.
+type connection struct {
+	site string
+	auth func(common.Authentication)
+}
+
+// newConnection determina o endereço da API e a autenticação de acordo com
+// jira.auth. No OAuth, troca o refresh token por um token de acesso, grava
+// o refresh token renovado e usa o gateway da Atlassian. Na reprodução de
+// gravações, nenhuma credencial é usada.
+func newConnection(cfg *config.Config, replay bool) (*connection, error) {
+	if replay {
+		return &connection{site: cfg.JiraURL, auth: func(common.Authentication) {}}, nil
+	}
+
+	switch cfg.JiraAuth {
+	case config.AuthBearer:
+		return &connection{
+			site: cfg.JiraURL,
+			auth: func(a common.Authentication) { a.SetBearerToken(cfg.JiraToken) },
+		}, nil
+	case config.AuthOAuth:
+		token, cloudID, err := refreshOAuthToken(cfg, http.DefaultClient)
+		if err != nil {
+			return nil, err
+		}
+		return &connection{
+			site: OAuthSite(cloudID),
+			auth: func(a common.Authentication) { a.SetBearerToken(token) },
+		}, nil
+	default:
+		return &connection{
+			site: cfg.JiraURL,
+			auth: func(a common.Authentication) {
+				a.SetBasicAuth(cfg.JiraEmail, cfg.JiraToken)
+			},
+		}, nil
+	}
+}
+
+// OAuthSite retorna o endereço da API do site do Jira Cloud acessado com um
+// token de acesso OAuth.
+func OAuthSite(cloudID string) string {
+	return oauthGateway + cloudID
+}
+
+// OAuthFlow conduz a autorização OAuth 2.0 (3LO) do Jira Cloud no auth
+// login.
+type OAuthFlow interface {
+	// AuthorizationURL retorna o endereço onde o usuário autoriza o app.
+	AuthorizationURL(state string) (string, error)
+	// Exchange troca o código de autorização pelos tokens e retorna o token
+	// de acesso e o refresh token.
+	Exchange(code string) (accessToken, refreshToken string, err error)
+	// CloudID retorna o cloudId do site do Jira configurado, acessível com
+	// o token de acesso.
+	CloudID(accessToken string) (string, error)
+}
+
+// oauthFlow implementa OAuthFlow com o serviço OAuth da Atlassian.
+type oauthFlow struct {
+	cfg     *config.Config
+	service common.OAuth2Service
+}
+
+// NewOAuthFlow cria o fluxo de autorização OAuth com o app configurado em
+// oauth.client_id, oauth.client_secret e oauth.redirect_uri. httpClient
+// permite substituir o cliente HTTP; quando nil, usa http.DefaultClient.
+func NewOAuthFlow(cfg *config.Config, httpClient *http.Client) (OAuthFlow, error) {
+	return newOAuthFlow(cfg, httpClient)
+}
+
+// newOAuthFlow cria o fluxo de autorização OAuth.
+func newOAuthFlow(cfg *config.Config, httpClient *http.Client) (*oauthFlow, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	service, err := oauth2.NewOAuth2Service(httpClient, &common.OAuth2Config{
+		ClientID:     cfg.OAuthClientID,
+		ClientSecret: cfg.OAuthClientSecret,
+		RedirectURI:  cfg.OAuthRedirectURI,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao configurar o OAuth: %w", err)
+	}
+	return &oauthFlow{cfg: cfg, service: service}, nil
+}
+
+// AuthorizationURL retorna o endereço onde o usuário autoriza o app.
+func (f *oauthFlow) AuthorizationURL(state string) (string, error) {
+	address, err := f.service.GetAuthorizationURL(oauthScopes, state)
+	if err != nil {
+		return "", err
+	}
+	return address.String(), nil
+}
+
+// Exchange troca o código de autorização pelos tokens.
+func (f *oauthFlow) Exchange(code string) (string, string, error) {
+	token, err := f.service.ExchangeAuthorizationCode(context.Background(), code)
+	if err != nil {
+		return "", "", fmt.Errorf("erro ao obter os tokens OAuth: %w", err)
+	}
+	if token.RefreshToken == "" {
+		return "", "", fmt.Errorf(
+			"o Jira não retornou um refresh token (verifique o escopo offline_access do app)",
+		)
+	}
+	return token.AccessToken, token.RefreshToken, nil
+}
+
+// CloudID retorna o cloudId configurado em oauth.cloud_id ou, quando vazio,
+// o do site acessível cujo endereço corresponde ao jira.url.
+func (f *oauthFlow) CloudID(accessToken string) (string, error) {
+	if f.cfg.OAuthCloudID != "" {
+		return f.cfg.OAuthCloudID, nil
+	}
+
+	resources, err := f.service.GetAccessibleResources(
+		context.Background(), accessToken,
+	)
+	if err != nil {
+		return "", fmt.Errorf("erro ao buscar os sites acessíveis: %w", err)
+	}
+
+	host := siteHost(f.cfg.JiraURL)
+	sites := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		if resource == nil {
+			continue
+		}
+		if siteHost(resource.URL) == host {
+			return resource.ID, nil
+		}
+		sites = append(sites, resource.URL)
+	}
+	return "", fmt.Errorf(
+		"o app OAuth não tem acesso a %s (sites autorizados: %s)",
+		f.cfg.JiraURL, strings.Join(sites, ", "),
+	)
+}
+
+// refreshOAuthToken troca o refresh token da configuração por um token de
+// acesso, gravando o refresh token renovado no provedor de credenciais, e
+// retorna o token de acesso e o cloudId do site.
+func refreshOAuthToken(
+	cfg *config.Config, httpClient *http.Client,
+) (string, string, error) {
+	flow, err := newOAuthFlow(cfg, httpClient)
+	if err != nil {
+		return "", "", err
+	}
+
+	token, err := flow.service.RefreshAccessToken(
+		context.Background(), cfg.JiraToken,
+	)
+	if err != nil {
+		return "", "", fmt.Errorf(
+			"erro ao renovar o token OAuth (use 'jira-reporter auth login'): %w",
+			err,
+		)
+	}
+
+	// O refresh token é rotativo: o anterior deixa de valer
+	if token.RefreshToken != "" && token.RefreshToken != cfg.JiraToken {
+		if err := cfg.StoreToken(token.RefreshToken); err != nil {
+			return "", "", fmt.Errorf(
+				"erro ao gravar o refresh token renovado: %w", err,
+			)
+		}
+	}
+
+	cloudID, err := flow.CloudID(token.AccessToken)
+	if err != nil {
+		return "", "", err
+	}
+	return token.AccessToken, cloudID, nil
+}
+
+// siteHost retorna o host do endereço, em minúsculas.
+func siteHost(address string) string {
+	parsed, err := url.Parse(address)
+	if err != nil {
+		return strings.ToLower(address)
+	}
+	return strings.ToLower(parsed.Host)
+}
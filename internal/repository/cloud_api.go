@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+	jira "github.com/ctreminiom/go-atlassian/v2/jira/v3"
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/v2/service/common"
+)
+
+// cloudAPI implementa jiraAPI com a API REST v3 do Jira Cloud.
+type cloudAPI struct {
+	client *jira.Client
+}
+
+// newCloudAPI cria o cliente da API REST v3.
+func newCloudAPI(
+	httpClient *http.Client, site string, auth func(common.Authentication),
+) (jiraAPI, error) {
+	client, err := jira.New(httpClient, site)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar cliente Jira: %w", err)
+	}
+	auth(client.Auth)
+
+	return &cloudAPI{client: client}, nil
+}
+
+// search busca uma página de issues seguindo o nextPageToken da busca JQL.
+func (a *cloudAPI) search(
+	ctx context.Context, jql string, fields, expand []string,
+	pageSize int, cursor string,
+) (*issuePage, *models.ResponseScheme, error) {
+	page, response, err := a.client.Issue.Search.SearchJQL(
+		ctx, jql, fields, expand, pageSize, cursor,
+	)
+	if err != nil {
+		return nil, response, err
+	}
+	if page == nil {
+		return &issuePage{}, response, nil
+	}
+
+	issues := make([]*apiIssue, 0, len(page.Issues))
+	for _, issue := range page.Issues {
+		description := model.Description{}
+		if issue.Fields != nil {
+			description = parseADF(issue.Fields.Description)
+		}
+		issues = append(issues, &apiIssue{
+			IssueScheme: issue,
+			description: description,
+		})
+	}
+	return &issuePage{issues: issues, next: page.NextPageToken}, response, nil
+}
+
+// worklogs busca uma página dos worklogs da issue.
+func (a *cloudAPI) worklogs(
+	ctx context.Context, issueKey string, startAt, pageSize int,
+	since time.Time,
+) (*worklogPage, *models.ResponseScheme, error) {
+	page, response, err := a.client.Issue.Worklog.Issue(
+		ctx, issueKey, startAt, pageSize, int(since.UnixMilli()), nil,
+	)
+	if err != nil {
+		return nil, response, err
+	}
+	if page == nil {
+		return &worklogPage{}, response, nil
+	}
+
+	worklogs := make([]*apiWorklog, 0, len(page.Worklogs))
+	for _, worklog := range page.Worklogs {
+		if worklog == nil {
+			continue
+		}
+		worklogs = append(worklogs, &apiWorklog{
+			author:  worklog.Author,
+			started: worklog.Started,
+			seconds: worklog.TimeSpentSeconds,
+		})
+	}
+	return &worklogPage{worklogs: worklogs, total: page.Total}, response, nil
+}
+
+// myself retorna o usuário autenticado.
+func (a *cloudAPI) myself(
+	ctx context.Context,
+) (*models.UserScheme, *models.ResponseScheme, error) {
+	return a.client.MySelf.Details(ctx, nil)
+}
+
+// searchUsers busca usuários pelo e-mail.
+func (a *cloudAPI) searchUsers(
+	ctx context.Context, email string, limit int,
+) ([]*models.UserScheme, *models.ResponseScheme, error) {
+	return a.client.User.Search.Do(ctx, "", email, 0, limit)
+}
+
+// permissions informa as permissões do usuário autenticado.
+func (a *cloudAPI) permissions(
+	ctx context.Context, keys []string,
+) (map[string]bool, *models.ResponseScheme, error) {
+	return myPermissions(ctx, a.client, "3", keys)
+}
+
+// fields retorna os campos das issues.
+func (a *cloudAPI) fields(
+	ctx context.Context,
+) ([]*models.IssueFieldScheme, *models.ResponseScheme, error) {
+	return a.client.Issue.Field.Gets(ctx)
+}
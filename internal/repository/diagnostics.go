@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+)
+
+// MissingPermissions retorna as permissões informadas que o usuário
+// autenticado não tem.
+func (r *jiraAPIRepository) MissingPermissions(keys []string) ([]string, error) {
+	granted, response, err := r.api.permissions(context.Background(), keys)
+	if err != nil {
+		if response != nil {
+			return nil, fmt.Errorf(
+				"erro ao consultar as permissões: %w - status: %s",
+				err, response.Status,
+			)
+		}
+		return nil, fmt.Errorf("erro ao consultar as permissões: %w", err)
+	}
+
+	var missing []string
+	for _, key := range keys {
+		if !granted[key] {
+			missing = append(missing, key)
+		}
+	}
+	return missing, nil
+}
+
+// MissingFields retorna os campos informados que não existem no Jira.
+func (r *jiraAPIRepository) MissingFields(names []string) ([]string, error) {
+	fields, response, err := r.api.fields(context.Background())
+	if err != nil {
+		if response != nil {
+			return nil, fmt.Errorf(
+				"erro ao consultar os campos: %w - status: %s",
+				err, response.Status,
+			)
+		}
+		return nil, fmt.Errorf("erro ao consultar os campos: %w", err)
+	}
+
+	var missing []string
+	for _, name := range names {
+		if !hasField(fields, name) {
+			missing = append(missing, name)
+		}
+	}
+	return missing, nil
+}
+
+// hasField verifica se o nome corresponde a um dos campos: pelo nome, pelo
+// id, pela chave ou por um dos nomes do campo na JQL. Na JQL, o tipo do
+// campo pode acompanhar o nome (ex: QA[User Picker (single user)]).
+func hasField(fields []*models.IssueFieldScheme, name string) bool {
+	plain := name
+	if i := strings.Index(name, "["); i > 0 && strings.HasSuffix(name, "]") {
+		plain = strings.TrimSpace(name[:i])
+	}
+
+	for _, field := range fields {
+		if field == nil {
+			continue
+		}
+		candidates := append([]string{field.Name, field.ID, field.Key}, field.ClauseNames...)
+		for _, candidate := range candidates {
+			if candidate == "" {
+				continue
+			}
+			if strings.EqualFold(candidate, name) || strings.EqualFold(candidate, plain) {
+				return true
+			}
+		}
+	}
+	return false
+}
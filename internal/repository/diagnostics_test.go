@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestMissingFieldsAndPermissions(t *testing.T) {
+	var requestedPermissions string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/field", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]map[string]any{
+			{"id": "summary", "name": "Resumo", "clauseNames": []string{"summary"}},
+			{
+				"id": "customfield_10050", "name": "QA", "custom": true,
+				"clauseNames": []string{"cf[10050]", "QA"},
+			},
+		})
+	})
+	mux.HandleFunc("/rest/api/3/mypermissions", func(w http.ResponseWriter, r *http.Request) {
+		requestedPermissions = r.URL.Query().Get("permissions")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"permissions": map[string]any{
+				"BROWSE_PROJECTS": map[string]any{"havePermission": true},
+				"WORK_ON_ISSUES":  map[string]any{"havePermission": false},
+			},
+		})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	repo := newTestRepository(t, server.URL, 100, 10)
+
+	missing, err := repo.MissingFields([]string{
+		"QA[User Picker (single user)]", "cf[10050]", "Revisor",
+	})
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if !reflect.DeepEqual(missing, []string{"Revisor"}) {
+		t.Errorf("campos ausentes esperados [Revisor], obtido %v", missing)
+	}
+
+	missing, err = repo.MissingPermissions([]string{"BROWSE_PROJECTS", "WORK_ON_ISSUES"})
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if !reflect.DeepEqual(missing, []string{"WORK_ON_ISSUES"}) {
+		t.Errorf("permissões ausentes esperadas [WORK_ON_ISSUES], obtido %v", missing)
+	}
+	if requestedPermissions != "BROWSE_PROJECTS,WORK_ON_ISSUES" {
+		t.Errorf("permissões consultadas inesperadas: %q", requestedPermissions)
+	}
+}
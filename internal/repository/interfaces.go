@@ -2,16 +2,48 @@
 package repository
 
 import (
+	"errors"
 	"time"
 
 	"github.com/alan-gomes1/jira-reporter/internal/model"
 )
 
+// ErrNoIssues indica que a busca não encontrou issues.
+var ErrNoIssues = errors.New("nenhuma issue encontrada no período")
+
+// IssueQuery descreve os critérios da busca de issues.
+type IssueQuery struct {
+	StartDate time.Time
+	EndDate   time.Time
+	IncludeQA bool               // Também busca issues onde o usuário é QA
+	Worklogs  bool               // Busca as horas registradas pelo usuário
+	Profile   model.QueryProfile // Perfil usado para montar a JQL
+	JQL       string             // JQL avulsa que substitui a do perfil (opcional)
+	SprintID  int                // Busca as issues da sprint em vez do período
+	Assignee  string             // accountId do usuário (vazio: usuário autenticado)
+}
+
 // JiraRepository define a interface para acesso aos dados do Jira.
 type JiraRepository interface {
-	// FetchIssues busca issues do Jira no período especificado.
-	// Se includeQA for true, também busca issues onde o usuário é QA.
-	FetchIssues(startDate, endDate time.Time, includeQA bool) (
-		*model.IssueCollection, error,
-	)
+	// FetchIssues busca issues do Jira de acordo com a consulta.
+	// Se query.IncludeQA for true, também busca issues onde o usuário é QA.
+	// Retorna ErrNoIssues quando a busca não encontra issues.
+	FetchIssues(query IssueQuery) (*model.IssueCollection, error)
+	// FetchSprint resolve a sprint pelo id, nome ou "last" (última encerrada).
+	// O board (id ou nome) é obrigatório quando a sprint não é um id.
+	FetchSprint(board, sprint string) (*model.Sprint, error)
+	// FindAccountID busca o accountId do usuário com o e-mail informado.
+	FindAccountID(email string) (string, error)
+	// TimeZone retorna o fuso horário do relatório: o configurado em
+	// TIMEZONE ou, quando vazio, o do perfil do usuário no Jira.
+	TimeZone() (*time.Location, error)
+	// Authenticate verifica as credenciais na API myself e retorna o nome do
+	// usuário autenticado.
+	Authenticate() (string, error)
+	// MissingPermissions retorna, entre as permissões informadas
+	// (ex: BROWSE_PROJECTS), as que o usuário autenticado não tem.
+	MissingPermissions(keys []string) ([]string, error)
+	// MissingFields retorna, entre os campos informados (nome, id ou nome na
+	// JQL, como os campos de QA dos perfis), os que não existem no Jira.
+	MissingFields(names []string) ([]string, error)
 }
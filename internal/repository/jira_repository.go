@@ -3,233 +3,396 @@ package repository
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/alan-gomes1/jira-reporter/internal/config"
 	"github.com/alan-gomes1/jira-reporter/internal/model"
-	jira "github.com/ctreminiom/go-atlassian/v2/jira/v3"
+	"github.com/ctreminiom/go-atlassian/v2/jira/agile"
 	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
 )
 
 // Constantes de formatação de data
 const (
-	jiraDateFormat    = "2006-01-02"
-	jiraTimeFormat    = "2006-01-02T15:04:05.999-0700"
-	displayDateFormat = "02/01"
+	jiraDateFormat = "2006-01-02"
+	jiraTimeFormat = "2006-01-02T15:04:05.999-0700"
+	jqlTimeFormat  = "2006-01-02 15:04"
 )
 
 // jiraAPIRepository implementa JiraRepository usando a API do Jira.
 type jiraAPIRepository struct {
-	client *jira.Client
-	config *config.Config
+	api      jiraAPI       // API REST v3 (Cloud) ou v2 (Server/Data Center)
+	agile    *agile.Client // Cliente da API Agile (boards e sprints)
+	config   *config.Config
+	progress io.Writer // Mensagens de progresso e avisos
+
+	// Dados carregados sob demanda
+	user            *models.UserScheme // Usuário autenticado (API myself)
+	location        *time.Location     // Fuso horário do relatório
+	profileLocation *time.Location     // Fuso do perfil no Jira, usado na JQL
 }
 
-// NewJiraRepository cria uma nova instância do repositório Jira.
-func NewJiraRepository(cfg *config.Config) (JiraRepository, error) {
-	client, err := jira.New(nil, cfg.JiraURL)
+// NewJiraRepository cria uma nova instância do repositório Jira, com a API
+// e a autenticação definidas em jira.deployment e jira.auth.
+// httpClient permite substituir o cliente HTTP usado nas chamadas (ex: para
+// gravar ou reproduzir respostas); quando nil, usa http.DefaultClient.
+// progress recebe as mensagens de progresso e os avisos da busca (nil
+// descarta as mensagens).
+func NewJiraRepository(
+	cfg *config.Config, httpClient *http.Client, progress io.Writer,
+) (JiraRepository, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if progress == nil {
+		progress = io.Discard
+	}
+
+	_, replay := httpClient.Transport.(*replayTransport)
+	conn, err := newConnection(cfg, replay)
 	if err != nil {
-		return nil, fmt.Errorf("erro ao criar cliente Jira: %w", err)
+		return nil, err
 	}
 
-	client.Auth.SetBasicAuth(cfg.JiraEmail, cfg.JiraToken)
+	api, err := newJiraAPI(cfg.JiraDeployment, httpClient, conn.site, conn.auth)
+	if err != nil {
+		return nil, err
+	}
+
+	agileClient, err := agile.New(httpClient, conn.site)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar cliente Jira Agile: %w", err)
+	}
+
+	conn.auth(agileClient.Auth)
 
 	return &jiraAPIRepository{
-		client: client,
-		config: cfg,
+		api:      api,
+		agile:    agileClient,
+		config:   cfg,
+		progress: progress,
 	}, nil
 }
 
-// FetchIssues busca issues do Jira no período especificado.
-// Se includeQA for true, também busca issues onde o usuário é QA.
+// FetchIssues busca issues do Jira de acordo com a consulta.
+// Se query.IncludeQA for true, também busca issues onde o usuário é QA.
 func (r *jiraAPIRepository) FetchIssues(
-	startDate, endDate time.Time, includeQA bool,
+	query IssueQuery,
 ) (*model.IssueCollection, error) {
-	jql := r.buildJQL(startDate, endDate, includeQA)
+	// As datas da JQL e das issues dependem dos fusos horários
+	if _, err := r.TimeZone(); err != nil {
+		return nil, err
+	}
+	if _, err := r.profileTimeZone(); err != nil {
+		return nil, err
+	}
+
+	jql := r.buildJQL(query)
+
+	issues, pages, err := r.searchAll(jql)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintf(r.progress,
+		"%d issue(s) obtida(s) do Jira em %d página(s)\n", len(issues), pages,
+	)
+
+	if len(issues) == 0 {
+		return nil, ErrNoIssues
+	}
+
+	collection := r.processIssues(issues, query.Profile)
+	r.sortByDate(collection)
+
+	if query.Worklogs {
+		if err := r.attachWorklogs(collection, query); err != nil {
+			return nil, err
+		}
+	}
+
+	return collection, nil
+}
+
+// searchAll percorre todas as páginas da busca JQL seguindo o cursor da
+// próxima página até que o resultado se esgote ou o limite de páginas seja
+// atingido.
+// Retorna as issues encontradas e a quantidade de páginas buscadas.
+func (r *jiraAPIRepository) searchAll(
+	jql string,
+) ([]*apiIssue, int, error) {
 	fields := r.getRequiredFields()
 	expand := []string{"changelog"}
 
-	issues, response, err := r.client.Issue.Search.SearchJQL(
-		context.Background(), jql, fields, expand, 100, "",
+	var (
+		issues    []*apiIssue
+		pageToken string
+		pages     int
 	)
-	if err != nil {
-		if response != nil {
-			return nil, fmt.Errorf(
-				"erro na busca de issues: %w - status: %s",
-				err,
-				response.Status,
+
+	for pages < r.config.SearchMaxPages {
+		page, response, err := r.api.search(
+			context.Background(), jql, fields, expand,
+			r.config.SearchPageSize, pageToken,
+		)
+		if err != nil {
+			if response != nil {
+				return nil, pages, fmt.Errorf(
+					"erro na busca de issues (página %d): %w - status: %s",
+					pages+1,
+					err,
+					response.Status,
+				)
+			}
+			return nil, pages, fmt.Errorf(
+				"erro na busca de issues (página %d): %w", pages+1, err,
 			)
 		}
-		return nil, fmt.Errorf("erro na busca de issues: %w", err)
-	}
+		pages++
 
-	if issues == nil || len(issues.Issues) == 0 {
-		return nil, fmt.Errorf("nenhuma issue encontrada no período")
+		issues = append(issues, page.issues...)
+
+		if page.next == "" {
+			return issues, pages, nil
+		}
+		pageToken = page.next
 	}
 
-	collection := r.processIssues(issues)
-	r.sortByDate(collection)
+	if pageToken != "" {
+		fmt.Fprintf(r.progress,
+			"Aviso: limite de %d página(s) atingido, "+
+				"o resultado pode estar incompleto (ajuste SEARCH_MAX_PAGES)\n",
+			r.config.SearchMaxPages,
+		)
+	}
 
-	return collection, nil
+	return issues, pages, nil
 }
 
 // buildJQL constrói a query JQL para buscar issues.
-func (r *jiraAPIRepository) buildJQL(
-	startDate, endDate time.Time, includeQA bool,
-) string {
-	firstDay := startDate.Format(jiraDateFormat)
-	lastDay := endDate.Format(jiraDateFormat)
+// Uma JQL avulsa tem prioridade sobre o perfil; em ambos os casos os
+// placeholders de período são substituídos.
+func (r *jiraAPIRepository) buildJQL(query IssueQuery) string {
+	if query.JQL != "" {
+		return r.expandPlaceholders(query.JQL, query)
+	}
+
+	profile := query.Profile
+	assignee := r.assigneeCondition(query)
+	periodCondition := r.expandPlaceholders(profile.BaseJQL, query)
+	if query.SprintID > 0 {
+		// No modo sprint, a participação na sprint substitui o período
+		periodCondition = fmt.Sprintf("sprint = %d", query.SprintID)
+	}
 
 	// Condição base: assignee é o usuário atual
-	baseCondition := fmt.Sprintf(
-		"assignee = %s AND (%s during ('%s', '%s') OR %s >= '%s' AND %s <= '%s')",
-		"currentUser()",
-		"status changed to 'In Progress'",
-		firstDay, lastDay,
-		"created", firstDay,
-		"created", lastDay,
-	)
+	conditions := []string{fmt.Sprintf(
+		"assignee = %s AND (%s)", assignee, periodCondition,
+	)}
 
 	// Se includeQA for true, adiciona condição para cards onde o usuário é QA
-	if includeQA {
-		qaCondition := fmt.Sprintf(
-			"'QA[User Picker (single user)]' = %s AND (%s during ('%s', '%s') OR %s >= '%s' AND %s <= '%s')",
-			"currentUser()",
-			"status changed to 'In Progress'",
-			firstDay, lastDay,
-			"created", firstDay,
-			"created", lastDay,
-		)
-		return fmt.Sprintf("(%s) OR (%s)", baseCondition, qaCondition)
+	if query.IncludeQA {
+		for _, field := range profile.QAFields {
+			conditions = append(conditions, fmt.Sprintf(
+				"%s = %s AND (%s)",
+				jqlQuote(field), assignee, periodCondition,
+			))
+		}
+	}
+
+	// Issues em que o usuário registrou horas no período. worklogDate
+	// ignora o horário, então os dias cobrem o período no fuso do perfil;
+	// os worklogs fora do período são descartados em fetchTimeSpent
+	if query.Worklogs {
+		start, end := r.jqlBounds(query)
+		conditions = append(conditions, fmt.Sprintf(
+			"worklogAuthor = %s AND worklogDate >= '%s' AND worklogDate <= '%s'",
+			assignee,
+			start.Format(jiraDateFormat),
+			end.Format(jiraDateFormat),
+		))
+	}
+
+	jql := conditions[0]
+	if len(conditions) > 1 {
+		jql = "(" + strings.Join(conditions, ") OR (") + ")"
+	}
+
+	// Filtros adicionais do perfil
+	var filters []string
+	if len(profile.Projects) > 0 {
+		filters = append(filters, "project in ("+jqlList(profile.Projects)+")")
+	}
+	if len(profile.Labels) > 0 {
+		filters = append(filters, "labels in ("+jqlList(profile.Labels)+")")
+	}
+	if len(filters) > 0 {
+		jql = fmt.Sprintf("(%s) AND %s", jql, strings.Join(filters, " AND "))
 	}
 
-	return baseCondition
+	return jql
+}
+
+// assigneeCondition retorna o usuário da consulta na JQL: o accountId
+// informado ou o usuário autenticado.
+func (r *jiraAPIRepository) assigneeCondition(query IssueQuery) string {
+	if query.Assignee != "" {
+		return jqlQuote(query.Assignee)
+	}
+	return "currentUser()"
+}
+
+// expandPlaceholders substitui {{start}}, {{end}} e {{started}} no template.
+// Os limites do período são informados com horário, no fuso do perfil do
+// usuário no Jira, para que cubram os dias inteiros no fuso do relatório.
+func (r *jiraAPIRepository) expandPlaceholders(
+	template string, query IssueQuery,
+) string {
+	start, end := r.jqlBounds(query)
+	firstDay := start.Format(jqlTimeFormat)
+	lastDay := end.Format(jqlTimeFormat)
+
+	started := make([]string, 0, len(query.Profile.StartedStatuses))
+	for _, status := range query.Profile.StartedStatuses {
+		started = append(started, fmt.Sprintf(
+			"status changed to %s during ('%s', '%s')",
+			jqlQuote(status), firstDay, lastDay,
+		))
+	}
+	startedCondition := strings.Join(started, " OR ")
+	if len(started) > 1 {
+		startedCondition = "(" + startedCondition + ")"
+	}
+
+	return strings.NewReplacer(
+		model.PlaceholderStart, firstDay,
+		model.PlaceholderEnd, lastDay,
+		model.PlaceholderStarted, startedCondition,
+	).Replace(template)
+}
+
+// jqlQuote delimita um valor com aspas simples, escapando as internas.
+func jqlQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `\'`) + "'"
+}
+
+// jqlList formata uma lista de valores para o operador "in".
+func jqlList(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, jqlQuote(value))
+	}
+	return strings.Join(quoted, ", ")
 }
 
 // getRequiredFields retorna os campos necessários para a busca.
 func (r *jiraAPIRepository) getRequiredFields() []string {
 	return []string{
 		"key", "summary", "description", "status", "created", "assignee",
+		"resolutiondate",
 	}
 }
 
 // processIssues converte as issues da API para o modelo de domínio.
 func (r *jiraAPIRepository) processIssues(
-	issues *models.IssueSearchJQLScheme,
+	issues []*apiIssue, profile model.QueryProfile,
 ) *model.IssueCollection {
 	collection := model.NewIssueCollection()
 
-	for _, issue := range issues.Issues {
-		issueDate := r.extractIssueDate(issue)
-		description := r.extractDescription(issue)
+	for _, issue := range issues {
 		url := r.buildIssueURL(issue.Key)
 
 		item := model.NewIssue(
 			issue.Key,
 			issue.Fields.Summary,
-			description,
-			issueDate,
+			issue.description,
+			r.localTime(issue.Fields.Created),
 			url,
 		)
+		item.Started = r.findInProgressDate(issue.IssueScheme, profile)
+		item.Assigned = r.findAssigneeDate(issue.IssueScheme)
+		item.Resolved = r.localTime(issue.Fields.Resolutiondate)
+		item.Status = r.extractStatus(issue.IssueScheme)
 		collection.Add(*item)
 	}
 
 	return collection
 }
 
-// extractIssueDate extrai a data relevante da issue (In Progress, atribuição ou criação).
-func (r *jiraAPIRepository) extractIssueDate(issue *models.IssueScheme) string {
-	inProgressDate := r.findInProgressDate(issue)
-	if inProgressDate != "" {
-		return inProgressDate
-	}
-
-	assigneeDate := r.findAssigneeDate(issue)
-	if assigneeDate != "" {
-		return assigneeDate
-	}
-
-	return r.parseCreatedDate(issue)
-}
-
-// findInProgressDate busca a data em que a issue entrou em "In Progress".
+// findInProgressDate busca a data em que a issue entrou em um dos status
+// de início do perfil (ex: "In Progress").
 func (r *jiraAPIRepository) findInProgressDate(
-	issue *models.IssueScheme,
-) string {
+	issue *models.IssueScheme, profile model.QueryProfile,
+) time.Time {
 	if issue.Changelog == nil {
-		return ""
+		return time.Time{}
 	}
 
 	for _, history := range issue.Changelog.Histories {
 		for _, item := range history.Items {
-			if item.Field == "status" && item.ToString == "In Progress" {
-				if date := r.parseJiraTime(history.Created); date != "" {
+			if item.Field == "status" && profile.IsStartedStatus(item.ToString) {
+				if date := r.parseJiraTime(history.Created); !date.IsZero() {
 					return date
 				}
 			}
 		}
 	}
-	return ""
+	return time.Time{}
 }
 
 // findAssigneeDate busca a data em que a issue foi atribuída ao usuário atual.
-func (r *jiraAPIRepository) findAssigneeDate(issue *models.IssueScheme) string {
-	noChangelog := issue.Changelog == nil
-	noFields := issue.Fields == nil
-	noAssignee := issue.Fields.Assignee == nil
-	if noChangelog || noFields || noAssignee {
-		return ""
+// O changelog identifica o usuário pelo accountId no Cloud e pela chave (ou
+// nome, em versões antigas) no Server/Data Center.
+func (r *jiraAPIRepository) findAssigneeDate(issue *models.IssueScheme) time.Time {
+	if issue.Changelog == nil || issue.Fields == nil ||
+		issue.Fields.Assignee == nil {
+		return time.Time{}
 	}
 
+	current := issue.Fields.Assignee
 	for _, history := range issue.Changelog.Histories {
 		for _, item := range history.Items {
 			assignee := item.Field == "assignee"
-			if assignee && item.To == issue.Fields.Assignee.AccountID {
-				if date := r.parseJiraTime(history.Created); date != "" {
+			if assignee && sameUser(
+				item.To, current.AccountID, current.Key, current.Name,
+			) {
+				if date := r.parseJiraTime(history.Created); !date.IsZero() {
 					return date
 				}
 			}
 		}
 	}
-	return ""
+	return time.Time{}
 }
 
-// parseCreatedDate extrai a data de criação da issue.
-func (r *jiraAPIRepository) parseCreatedDate(issue *models.IssueScheme) string {
-	if issue.Fields == nil || issue.Fields.Created == nil {
-		return ""
+// localTime converte uma data da API para o fuso horário do relatório.
+// Retorna o instante zero quando a data não foi informada.
+func (r *jiraAPIRepository) localTime(date *models.DateTimeScheme) time.Time {
+	if date == nil || time.Time(*date).IsZero() {
+		return time.Time{}
 	}
-	created := fmt.Sprintf("%v", issue.Fields.Created)
-	return r.parseJiraTime(created)
+	return time.Time(*date).In(r.location)
 }
 
-// parseJiraTime converte uma string de data do Jira para o formato de exibição.
-func (r *jiraAPIRepository) parseJiraTime(dateStr string) string {
+// parseJiraTime converte uma string de data do Jira para o fuso horário do
+// relatório. Retorna o instante zero quando a data é inválida.
+func (r *jiraAPIRepository) parseJiraTime(dateStr string) time.Time {
 	parsedDate, err := time.Parse(jiraTimeFormat, dateStr)
 	if err != nil {
-		return ""
+		return time.Time{}
 	}
-	return parsedDate.Format(displayDateFormat)
+	return parsedDate.In(r.location)
 }
 
-// extractDescription extrai o texto da descrição da issue.
-func (r *jiraAPIRepository) extractDescription(
-	issue *models.IssueScheme,
-) string {
-	if issue.Fields == nil {
-		return ""
-	}
-	desc := issue.Fields.Description
-	if desc == nil || len(desc.Content) == 0 {
-		return ""
-	}
-	if desc.Content[0] == nil || len(desc.Content[0].Content) == 0 {
-		return ""
-	}
-	if desc.Content[0].Content[0] == nil {
+// extractStatus extrai o nome do status atual da issue.
+func (r *jiraAPIRepository) extractStatus(issue *models.IssueScheme) string {
+	if issue.Fields == nil || issue.Fields.Status == nil {
 		return ""
 	}
-	return desc.Content[0].Content[0].Text
+	return issue.Fields.Status.Name
 }
 
 // buildIssueURL constrói a URL da issue.
@@ -237,9 +400,10 @@ func (r *jiraAPIRepository) buildIssueURL(key string) string {
 	return fmt.Sprintf("%s/browse/%s", r.config.JiraURL, key)
 }
 
-// sortByDate ordena a coleção de issues por data.
+// sortByDate ordena a coleção de issues pela data relevante de cada uma,
+// mantendo a ordem da busca entre issues da mesma data.
 func (r *jiraAPIRepository) sortByDate(collection *model.IssueCollection) {
-	sort.Slice(collection.Items, func(i, j int) bool {
-		return collection.Items[i].Date < collection.Items[j].Date
+	sort.SliceStable(collection.Items, func(i, j int) bool {
+		return collection.Items[i].Date().Before(collection.Items[j].Date())
 	})
 }
@@ -0,0 +1,543 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alan-gomes1/jira-reporter/internal/config"
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+)
+
+// searchRequest representa o payload enviado ao endpoint de busca JQL.
+type searchRequest struct {
+	JQL           string   `json:"jql"`
+	Fields        []string `json:"fields"`
+	MaxResults    int      `json:"maxResults"`
+	NextPageToken string   `json:"nextPageToken"`
+}
+
+// fakeSearchServer simula o endpoint /rest/api/3/search/jql do Jira,
+// devolvendo totalIssues issues paginadas de acordo com o maxResults
+// recebido. Como o Jira, a data de resolução só é devolvida quando o campo
+// resolutiondate é solicitado. As requisições recebidas ficam registradas
+// em requests. O endpoint myself informa o fuso horário UTC no perfil do
+// usuário.
+type fakeSearchServer struct {
+	*httptest.Server
+	totalIssues int
+	requests    []searchRequest
+}
+
+func newFakeSearchServer(t *testing.T, totalIssues int) *fakeSearchServer {
+	t.Helper()
+
+	fake := &fakeSearchServer{totalIssues: totalIssues}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/search/jql", fake.handleSearch)
+	mux.HandleFunc("/rest/api/3/myself", handleMyself("UTC"))
+	fake.Server = httptest.NewServer(mux)
+	t.Cleanup(fake.Close)
+
+	return fake
+}
+
+func (f *fakeSearchServer) handleSearch(w http.ResponseWriter, r *http.Request) {
+	var req searchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	f.requests = append(f.requests, req)
+
+	offset := 0
+	if req.NextPageToken != "" {
+		fmt.Sscanf(req.NextPageToken, "offset-%d", &offset)
+	}
+
+	end := offset + req.MaxResults
+	if end > f.totalIssues {
+		end = f.totalIssues
+	}
+
+	issues := make([]map[string]any, 0, end-offset)
+	for i := offset; i < end; i++ {
+		fields := map[string]any{
+			"summary": fmt.Sprintf("Issue %d", i+1),
+			"created": "2025-01-10T10:00:00.000-0300",
+		}
+		if slices.Contains(req.Fields, "resolutiondate") {
+			fields["resolutiondate"] = "2025-01-12T15:30:00.000-0300"
+		}
+		issues = append(issues, map[string]any{
+			"key":    fmt.Sprintf("PROJ-%d", i+1),
+			"fields": fields,
+		})
+	}
+
+	response := map[string]any{"issues": issues}
+	if end < f.totalIssues {
+		response["nextPageToken"] = fmt.Sprintf("offset-%d", end)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// handleMyself simula o endpoint myself com o fuso horário informado.
+func handleMyself(timeZone string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"accountId": testAccountID,
+			"timeZone":  timeZone,
+		})
+	}
+}
+
+func newTestRepository(
+	t *testing.T, url string, pageSize, maxPages int,
+) JiraRepository {
+	t.Helper()
+
+	cfg := &config.Config{
+		JiraURL:        url,
+		JiraEmail:      "user@example.com",
+		JiraToken:      "token",
+		SearchPageSize: pageSize,
+		SearchMaxPages: maxPages,
+	}
+	repo, err := NewJiraRepository(cfg, nil, nil)
+	if err != nil {
+		t.Fatalf("erro ao criar repositório: %v", err)
+	}
+	return repo
+}
+
+// newJQLTestRepository cria um repositório sem cliente, com os fusos
+// horários do relatório e do perfil no Jira já resolvidos.
+func newJQLTestRepository(location, profileLocation *time.Location) *jiraAPIRepository {
+	return &jiraAPIRepository{
+		config:          &config.Config{},
+		progress:        io.Discard,
+		location:        location,
+		profileLocation: profileLocation,
+	}
+}
+
+func testQuery() IssueQuery {
+	start := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return IssueQuery{
+		StartDate: start,
+		EndDate:   start.AddDate(0, 1, -1),
+		Profile:   model.NewDefaultQueryProfile(),
+	}
+}
+
+func TestFetchIssuesFollowsNextPageToken(t *testing.T) {
+	server := newFakeSearchServer(t, 250)
+	repo := newTestRepository(t, server.URL, 100, 10)
+
+	issues, err := repo.FetchIssues(testQuery())
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	if issues.Count() != 250 {
+		t.Errorf("esperado 250 issues, obtido %d", issues.Count())
+	}
+	if len(server.requests) != 3 {
+		t.Fatalf("esperado 3 páginas, obtido %d", len(server.requests))
+	}
+
+	expectedTokens := []string{"", "offset-100", "offset-200"}
+	for i, req := range server.requests {
+		if req.NextPageToken != expectedTokens[i] {
+			t.Errorf(
+				"página %d: token esperado %q, obtido %q",
+				i+1, expectedTokens[i], req.NextPageToken,
+			)
+		}
+		if req.MaxResults != 100 {
+			t.Errorf(
+				"página %d: maxResults esperado 100, obtido %d",
+				i+1, req.MaxResults,
+			)
+		}
+	}
+}
+
+func TestFetchIssuesReadsResolutionDate(t *testing.T) {
+	server := newFakeSearchServer(t, 1)
+	repo := newTestRepository(t, server.URL, 100, 10)
+
+	issues, err := repo.FetchIssues(testQuery())
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	expected := time.Date(2025, time.January, 12, 18, 30, 0, 0, time.UTC)
+	if got := issues.Items[0].Resolved; !got.Equal(expected) {
+		t.Errorf("data de resolução esperada %v, obtido %v", expected, got)
+	}
+}
+
+func TestFetchIssuesUsesConfiguredPageSize(t *testing.T) {
+	server := newFakeSearchServer(t, 7)
+	repo := newTestRepository(t, server.URL, 3, 10)
+
+	issues, err := repo.FetchIssues(testQuery())
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	if issues.Count() != 7 {
+		t.Errorf("esperado 7 issues, obtido %d", issues.Count())
+	}
+	if len(server.requests) != 3 {
+		t.Errorf("esperado 3 páginas, obtido %d", len(server.requests))
+	}
+}
+
+func TestFetchIssuesStopsAtMaxPages(t *testing.T) {
+	server := newFakeSearchServer(t, 50)
+	repo := newTestRepository(t, server.URL, 10, 2)
+	var progress strings.Builder
+	repo.(*jiraAPIRepository).progress = &progress
+
+	issues, err := repo.FetchIssues(testQuery())
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	if issues.Count() != 20 {
+		t.Errorf("esperado 20 issues, obtido %d", issues.Count())
+	}
+	if len(server.requests) != 2 {
+		t.Errorf("esperado 2 páginas, obtido %d", len(server.requests))
+	}
+	// O aviso do limite de páginas vai para o progresso informado
+	if !strings.Contains(progress.String(), "Aviso") {
+		t.Errorf("esperado aviso do limite de páginas no progresso, obtido %q", progress.String())
+	}
+}
+
+func TestFetchIssuesReturnsErrorWhenEmpty(t *testing.T) {
+	server := newFakeSearchServer(t, 0)
+	repo := newTestRepository(t, server.URL, 100, 10)
+
+	if _, err := repo.FetchIssues(testQuery()); !errors.Is(err, ErrNoIssues) {
+		t.Fatalf("esperado ErrNoIssues quando nenhuma issue é encontrada, obtido %v", err)
+	}
+	if len(server.requests) != 1 {
+		t.Errorf("esperado 1 página, obtido %d", len(server.requests))
+	}
+}
+
+func TestFetchIssuesReportsHTTPErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, `{"errorMessages":["JQL inválida"]}`, http.StatusBadRequest)
+		},
+	))
+	t.Cleanup(server.Close)
+	repo := newTestRepository(t, server.URL, 100, 10)
+
+	if _, err := repo.FetchIssues(testQuery()); err == nil {
+		t.Fatal("esperado erro para resposta HTTP 400")
+	}
+}
+
+func TestBuildJQLWithDefaultProfile(t *testing.T) {
+	repo := newJQLTestRepository(time.UTC, time.UTC)
+
+	query := testQuery()
+	expected := "assignee = currentUser() AND (status changed to " +
+		"'In Progress' during ('2025-01-01 00:00', '2025-01-31 23:59') OR " +
+		"created >= '2025-01-01 00:00' AND created <= '2025-01-31 23:59')"
+	if jql := repo.buildJQL(query); jql != expected {
+		t.Errorf("JQL inesperada:\n%s\nesperado:\n%s", jql, expected)
+	}
+}
+
+func TestBuildJQLWithCustomProfile(t *testing.T) {
+	repo := newJQLTestRepository(time.UTC, time.UTC)
+
+	query := testQuery()
+	query.IncludeQA = true
+	query.Profile = model.QueryProfile{
+		Name:            "cliente",
+		BaseJQL:         "{{started}}",
+		StartedStatuses: []string{"Em andamento", "Doing"},
+		QAFields:        []string{"Revisor"},
+		Projects:        []string{"ABC"},
+		Labels:          []string{"faturavel"},
+	}
+
+	started := "(status changed to 'Em andamento' during " +
+		"('2025-01-01 00:00', '2025-01-31 23:59') OR status changed to 'Doing' " +
+		"during ('2025-01-01 00:00', '2025-01-31 23:59'))"
+	expected := "((assignee = currentUser() AND (" + started + ")) OR " +
+		"('Revisor' = currentUser() AND (" + started + "))) AND " +
+		"project in ('ABC') AND labels in ('faturavel')"
+	if jql := repo.buildJQL(query); jql != expected {
+		t.Errorf("JQL inesperada:\n%s\nesperado:\n%s", jql, expected)
+	}
+}
+
+func TestBuildJQLOverrideExpandsPlaceholders(t *testing.T) {
+	repo := newJQLTestRepository(time.UTC, time.UTC)
+
+	query := testQuery()
+	query.JQL = "project = XYZ AND updated >= '{{start}}' AND updated <= '{{end}}'"
+
+	expected := "project = XYZ AND updated >= '2025-01-01 00:00' AND " +
+		"updated <= '2025-01-31 23:59'"
+	if jql := repo.buildJQL(query); jql != expected {
+		t.Errorf("JQL inesperada:\n%s\nesperado:\n%s", jql, expected)
+	}
+}
+
+func TestBuildJQLWithSprintAndWorklogs(t *testing.T) {
+	repo := newJQLTestRepository(time.UTC, time.UTC)
+
+	query := testQuery()
+	query.SprintID = 42
+	query.Worklogs = true
+
+	expected := "(assignee = currentUser() AND (sprint = 42)) OR " +
+		"(worklogAuthor = currentUser() AND worklogDate >= '2025-01-01' " +
+		"AND worklogDate <= '2025-01-31')"
+	if jql := repo.buildJQL(query); jql != expected {
+		t.Errorf("JQL inesperada:\n%s\nesperado:\n%s", jql, expected)
+	}
+}
+
+func TestBuildJQLWithAssignee(t *testing.T) {
+	repo := newJQLTestRepository(time.UTC, time.UTC)
+
+	query := testQuery()
+	query.IncludeQA = true
+	query.Assignee = "5b10ac8d82e05b22cc7d4ef5"
+	query.Profile.BaseJQL = "created >= '{{start}}'"
+
+	expected := "(assignee = '5b10ac8d82e05b22cc7d4ef5' AND " +
+		"(created >= '2025-01-01 00:00')) OR " +
+		"('QA[User Picker (single user)]' = '5b10ac8d82e05b22cc7d4ef5' " +
+		"AND (created >= '2025-01-01 00:00'))"
+	if jql := repo.buildJQL(query); jql != expected {
+		t.Errorf("JQL inesperada:\n%s\nesperado:\n%s", jql, expected)
+	}
+}
+
+func TestFetchIssuesSortsByDateAcrossMonthsAndYears(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/myself", handleMyself("America/Sao_Paulo"))
+	mux.HandleFunc("/rest/api/3/search/jql",
+		func(w http.ResponseWriter, r *http.Request) {
+			issue := func(key, created string, histories ...any) map[string]any {
+				return map[string]any{
+					"key":       key,
+					"fields":    map[string]any{"summary": key, "created": created},
+					"changelog": map[string]any{"histories": histories},
+				}
+			}
+			started := map[string]any{
+				"created": "2024-12-30T09:00:00.000-0300",
+				"items": []any{map[string]any{
+					"field": "status", "toString": "In Progress",
+				}},
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{"issues": []any{
+				issue("PROJ-1", "2025-02-01T08:00:00.000-0300"),
+				issue("PROJ-2", "2025-01-15T08:00:00.000-0300"),
+				issue("PROJ-3", "2024-11-20T08:00:00.000-0300", started),
+				issue("PROJ-4", "2024-12-31T23:30:00.000-0300"),
+			}})
+		},
+	)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	repo := newTestRepository(t, server.URL, 100, 10)
+
+	issues, err := repo.FetchIssues(testQuery())
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	// PROJ-3 usa a data de início do trabalho, não a de criação
+	expected := []string{"PROJ-3", "PROJ-4", "PROJ-2", "PROJ-1"}
+	for i, key := range expected {
+		if issues.Items[i].Key != key {
+			t.Errorf("posição %d: esperado %s, obtido %s", i, key, issues.Items[i].Key)
+		}
+	}
+
+	created := time.Date(2024, time.December, 31, 23, 30, 0, 0, time.FixedZone("", -3*3600))
+	if !issues.Items[1].Created.Equal(created) {
+		t.Errorf("data de criação esperada %v, obtida %v", created, issues.Items[1].Created)
+	}
+	if issues.Items[0].Started.IsZero() {
+		t.Error("esperada a data de início do trabalho de PROJ-3")
+	}
+}
+
+func TestBuildJQLConvertsMonthToProfileTimeZone(t *testing.T) {
+	saoPaulo, _ := time.LoadLocation("America/Sao_Paulo")
+	repo := newJQLTestRepository(saoPaulo, time.UTC)
+
+	query := testQuery()
+	query.JQL = "created >= '{{start}}' AND created <= '{{end}}'"
+
+	// Janeiro em São Paulo (UTC-3) vai das 03:00 de 01/01 às 02:59 de 01/02 em UTC
+	expected := "created >= '2025-01-01 03:00' AND created <= '2025-02-01 02:59'"
+	if jql := repo.buildJQL(query); jql != expected {
+		t.Errorf("JQL inesperada:\n%s\nesperado:\n%s", jql, expected)
+	}
+
+	query.JQL = ""
+	query.SprintID = 42
+	query.Worklogs = true
+	expected = "(assignee = currentUser() AND (sprint = 42)) OR " +
+		"(worklogAuthor = currentUser() AND worklogDate >= '2025-01-01' " +
+		"AND worklogDate <= '2025-02-01')"
+	if jql := repo.buildJQL(query); jql != expected {
+		t.Errorf("JQL inesperada:\n%s\nesperado:\n%s", jql, expected)
+	}
+}
+
+func TestTimeZonePrefersConfiguredValue(t *testing.T) {
+	repo := newTestRepository(t, "http://127.0.0.1:1", 100, 10)
+	repo.(*jiraAPIRepository).config.TimeZone = "Europe/Lisbon"
+
+	location, err := repo.TimeZone()
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if location.String() != "Europe/Lisbon" {
+		t.Errorf("fuso esperado Europe/Lisbon, obtido %s", location)
+	}
+}
+
+// newMonthBoundaryServer simula um Jira cujo usuário está em São Paulo,
+// com uma issue iniciada e com horas registradas na virada de janeiro para
+// fevereiro (em UTC).
+func newMonthBoundaryServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/myself", handleMyself("America/Sao_Paulo"))
+	mux.HandleFunc("/rest/api/3/search/jql",
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{"issues": []any{
+				map[string]any{
+					"key": "PROJ-1",
+					"fields": map[string]any{
+						"summary": "Virada do mês",
+						"created": "2025-01-20T12:00:00.000+0000",
+					},
+					"changelog": map[string]any{"histories": []any{
+						map[string]any{
+							"created": "2025-02-01T01:30:00.000+0000",
+							"items": []any{map[string]any{
+								"field": "status", "toString": "In Progress",
+							}},
+						},
+					}},
+				},
+			}})
+		},
+	)
+	mux.HandleFunc("/rest/api/3/issue/PROJ-1/worklog",
+		func(w http.ResponseWriter, r *http.Request) {
+			worklog := func(started string, seconds int) map[string]any {
+				return map[string]any{
+					"author":           map[string]any{"accountId": "5b10ac8d82e05b22cc7d4ef5"},
+					"started":          started,
+					"timeSpentSeconds": seconds,
+				}
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{
+				"total": 3,
+				"worklogs": []any{
+					// 31/12 às 23:00 em São Paulo: fora de janeiro
+					worklog("2025-01-01T02:00:00.000+0000", 1800),
+					// 31/01 às 22:30 em São Paulo: dentro de janeiro
+					worklog("2025-02-01T01:30:00.000+0000", 3600),
+					// 01/02 às 00:30 em São Paulo: fora de janeiro
+					worklog("2025-02-01T03:30:00.000+0000", 7200),
+				},
+			})
+		},
+	)
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetchIssuesUsesProfileTimeZoneAtMonthBoundary(t *testing.T) {
+	server := newMonthBoundaryServer(t)
+	repo := newTestRepository(t, server.URL, 100, 10)
+
+	query := testQuery()
+	query.Worklogs = true
+	issues, err := repo.FetchIssues(query)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	started := issues.Items[0].Started
+	if started.Location().String() != "America/Sao_Paulo" {
+		t.Errorf("fuso esperado America/Sao_Paulo, obtido %s", started.Location())
+	}
+	if started.Month() != time.January || started.Day() != 31 {
+		t.Errorf("início esperado em 31/01, obtido %s", started.Format("02/01 15:04"))
+	}
+
+	timeSpent := issues.Items[0].TimeSpent
+	if len(timeSpent) != 1 {
+		t.Fatalf("esperado 1 dia com horas, obtido %d: %v", len(timeSpent), timeSpent)
+	}
+	if day := timeSpent[0].Date; day.Month() != time.January || day.Day() != 31 {
+		t.Errorf("dia esperado 31/01, obtido %s", day.Format("02/01"))
+	}
+	if timeSpent[0].Seconds != 3600 {
+		t.Errorf("esperado 3600 segundos, obtido %d", timeSpent[0].Seconds)
+	}
+}
+
+func TestFetchIssuesUsesConfiguredTimeZoneAtMonthBoundary(t *testing.T) {
+	server := newMonthBoundaryServer(t)
+	repo := newTestRepository(t, server.URL, 100, 10)
+	repo.(*jiraAPIRepository).config.TimeZone = "UTC"
+
+	query := testQuery()
+	query.Worklogs = true
+	issues, err := repo.FetchIssues(query)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	// Em UTC, o início do trabalho e as horas de 01/02 ficam fora de janeiro
+	started := issues.Items[0].Started
+	if started.Month() != time.February || started.Day() != 1 {
+		t.Errorf("início esperado em 01/02, obtido %s", started.Format("02/01 15:04"))
+	}
+
+	timeSpent := issues.Items[0].TimeSpent
+	if len(timeSpent) != 1 || timeSpent[0].Seconds != 1800 {
+		t.Errorf("esperadas apenas as horas de 01/01, obtido %v", timeSpent)
+	}
+}
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// recordingKeyLength é a quantidade de caracteres do hash usada no nome dos
+// arquivos gravados.
+const recordingKeyLength = 16
+
+// recording é a resposta gravada de uma requisição ao Jira. O corpo da
+// requisição e da resposta são mantidos como JSON para facilitar a edição.
+type recording struct {
+	Method   string          `json:"method"`
+	Path     string          `json:"path"`
+	Request  json.RawMessage `json:"request,omitempty"`
+	Status   int             `json:"status"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Text     string          `json:"text,omitempty"` // Resposta que não é JSON
+}
+
+// NewRecordingClient cria um cliente HTTP que acessa o Jira normalmente e
+// grava cada resposta recebida no diretório informado. O diretório usado é
+// informado em progress.
+func NewRecordingClient(dir string, progress io.Writer) (*http.Client, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("erro ao criar diretório de gravação %s: %w", dir, err)
+	}
+	fmt.Fprintf(progress, "Gravando as respostas do Jira em %s\n", dir)
+
+	return &http.Client{
+		Transport: &recordingTransport{dir: dir, base: http.DefaultTransport},
+	}, nil
+}
+
+// NewReplayClient cria um cliente HTTP que responde às requisições a partir
+// das gravações do diretório informado, sem acessar a rede. O diretório
+// usado é informado em progress.
+func NewReplayClient(dir string, progress io.Writer) (*http.Client, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir diretório de gravação %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s não é um diretório", dir)
+	}
+	fmt.Fprintf(progress, "Reproduzindo as respostas gravadas em %s\n", dir)
+
+	return &http.Client{Transport: &replayTransport{dir: dir}}, nil
+}
+
+// recordingTransport executa as requisições e grava as respostas.
+type recordingTransport struct {
+	dir  string
+	base http.RoundTripper
+}
+
+// RoundTrip executa a requisição e grava a resposta antes de devolvê-la.
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler resposta do Jira: %w", err)
+	}
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	rec := recording{
+		Method:  req.Method,
+		Path:    req.URL.RequestURI(),
+		Request: jsonOrNil(requestBody),
+		Status:  response.StatusCode,
+	}
+	if json.Valid(responseBody) {
+		rec.Response = responseBody
+	} else {
+		rec.Text = string(responseBody)
+	}
+
+	content, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao serializar gravação: %w", err)
+	}
+	path := recordingPath(t.dir, req, requestBody)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return nil, fmt.Errorf("erro ao gravar resposta em %s: %w", path, err)
+	}
+
+	return response, nil
+}
+
+// replayTransport responde às requisições a partir das gravações.
+type replayTransport struct {
+	dir string
+}
+
+// RoundTrip devolve a resposta gravada para a requisição.
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	path := recordingPath(t.dir, req, requestBody)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"resposta não gravada para %s %s (%s): %w",
+			req.Method, req.URL.RequestURI(), filepath.Base(path), err,
+		)
+	}
+
+	var rec recording
+	if err := json.Unmarshal(content, &rec); err != nil {
+		return nil, fmt.Errorf("gravação inválida em %s: %w", path, err)
+	}
+
+	body := []byte(rec.Text)
+	if len(rec.Response) > 0 {
+		body = rec.Response
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.Status, http.StatusText(rec.Status)),
+		StatusCode:    rec.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// readRequestBody lê o corpo da requisição, recolocando-o para o envio.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler requisição ao Jira: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// gatewayPrefix é o prefixo dos caminhos no gateway OAuth da Atlassian.
+var gatewayPrefix = regexp.MustCompile(`^/ex/jira/[^/]+`)
+
+// recordingPath retorna o arquivo da gravação da requisição. O nome combina
+// o endpoint (legível) com o hash do método, caminho e corpo, ignorando o
+// host para que a gravação possa ser reproduzida com qualquer URL do Jira.
+// O prefixo /ex/jira/<cloudId> do gateway OAuth também é ignorado, para que
+// gravações feitas com OAuth sejam reproduzidas sem autenticação.
+func recordingPath(dir string, req *http.Request, body []byte) string {
+	uri := gatewayPrefix.ReplaceAllString(req.URL.RequestURI(), "")
+	path := gatewayPrefix.ReplaceAllString(req.URL.Path, "")
+
+	hash := sha256.New()
+	hash.Write([]byte(req.Method + " " + uri + "\n"))
+	hash.Write(body)
+	key := hex.EncodeToString(hash.Sum(nil))[:recordingKeyLength]
+
+	endpoint := strings.Trim(strings.TrimPrefix(path, "/rest/"), "/")
+	endpoint = strings.NewReplacer("/", "-", ".", "-").Replace(endpoint)
+
+	return filepath.Join(dir, endpoint+"_"+key+".json")
+}
+
+// jsonOrNil retorna o corpo como JSON, ou nil quando vazio ou inválido.
+func jsonOrNil(body []byte) json.RawMessage {
+	if len(body) == 0 || !json.Valid(body) {
+		return nil
+	}
+	return body
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/alan-gomes1/jira-reporter/internal/config"
+)
+
+func TestReplayReproducesRecordedSearch(t *testing.T) {
+	fake := newFakeSearchServer(t, 5)
+	dir := t.TempDir()
+
+	cfg := &config.Config{
+		JiraURL:        fake.URL,
+		JiraEmail:      "user@example.com",
+		JiraToken:      "token",
+		SearchPageSize: 2,
+		SearchMaxPages: 10,
+	}
+
+	recorder, err := NewRecordingClient(dir, io.Discard)
+	if err != nil {
+		t.Fatalf("erro ao criar cliente de gravação: %v", err)
+	}
+	repo, err := NewJiraRepository(cfg, recorder, nil)
+	if err != nil {
+		t.Fatalf("erro ao criar repositório: %v", err)
+	}
+	recorded, err := repo.FetchIssues(testQuery())
+	if err != nil {
+		t.Fatalf("erro inesperado na gravação: %v", err)
+	}
+
+	files, _ := os.ReadDir(dir)
+	// Um arquivo por página, mais a consulta do fuso horário (myself)
+	if len(files) != 4 {
+		t.Fatalf("esperado 4 arquivos gravados, obtido %d", len(files))
+	}
+
+	// A reprodução não acessa a rede: o servidor é encerrado e a URL trocada
+	fake.Close()
+	cfg.JiraURL = "http://jira.invalid"
+
+	player, err := NewReplayClient(dir, io.Discard)
+	if err != nil {
+		t.Fatalf("erro ao criar cliente de reprodução: %v", err)
+	}
+	repo, err = NewJiraRepository(cfg, player, nil)
+	if err != nil {
+		t.Fatalf("erro ao criar repositório: %v", err)
+	}
+	replayed, err := repo.FetchIssues(testQuery())
+	if err != nil {
+		t.Fatalf("erro inesperado na reprodução: %v", err)
+	}
+
+	if replayed.Count() != recorded.Count() {
+		t.Fatalf(
+			"esperado %d issues na reprodução, obtido %d",
+			recorded.Count(), replayed.Count(),
+		)
+	}
+	for i := range recorded.Items {
+		if replayed.Items[i].Key != recorded.Items[i].Key {
+			t.Errorf(
+				"issue %d: esperado %s, obtido %s",
+				i, recorded.Items[i].Key, replayed.Items[i].Key,
+			)
+		}
+	}
+}
+
+func TestReplayFailsForUnrecordedRequest(t *testing.T) {
+	cfg := &config.Config{
+		JiraURL:        "http://jira.invalid",
+		SearchPageSize: 10,
+		SearchMaxPages: 1,
+	}
+
+	player, err := NewReplayClient(t.TempDir(), io.Discard)
+	if err != nil {
+		t.Fatalf("erro ao criar cliente de reprodução: %v", err)
+	}
+	repo, err := NewJiraRepository(cfg, player, nil)
+	if err != nil {
+		t.Fatalf("erro ao criar repositório: %v", err)
+	}
+
+	if _, err := repo.FetchIssues(testQuery()); err == nil {
+		t.Fatal("esperado erro para requisição não gravada")
+	}
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	jira "github.com/ctreminiom/go-atlassian/v2/jira/v2"
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/v2/service/common"
+)
+
+// serverAPI implementa jiraAPI com a API REST v2 do Jira Server/Data
+// Center. A busca é paginada por startAt, as descrições usam wiki markup e
+// os usuários são identificados pelo nome.
+type serverAPI struct {
+	client *jira.Client
+}
+
+// newServerAPI cria o cliente da API REST v2.
+func newServerAPI(
+	httpClient *http.Client, site string, auth func(common.Authentication),
+) (jiraAPI, error) {
+	client, err := jira.New(httpClient, site)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar cliente Jira: %w", err)
+	}
+	auth(client.Auth)
+
+	return &serverAPI{client: client}, nil
+}
+
+// search busca uma página de issues. O cursor é a posição (startAt) da
+// primeira issue da página.
+func (a *serverAPI) search(
+	ctx context.Context, jql string, fields, expand []string,
+	pageSize int, cursor string,
+) (*issuePage, *models.ResponseScheme, error) {
+	startAt := 0
+	if cursor != "" {
+		var err error
+		if startAt, err = strconv.Atoi(cursor); err != nil {
+			return nil, nil, fmt.Errorf("cursor de paginação inválido: %s", cursor)
+		}
+	}
+
+	page, response, err := a.client.Issue.Search.Post(
+		ctx, jql, fields, expand, startAt, pageSize, "",
+	)
+	if err != nil {
+		return nil, response, err
+	}
+	if page == nil {
+		return &issuePage{}, response, nil
+	}
+
+	issues := make([]*apiIssue, 0, len(page.Issues))
+	for _, issue := range page.Issues {
+		issues = append(issues, toAPIIssue(issue))
+	}
+
+	result := &issuePage{issues: issues}
+	if next := startAt + len(page.Issues); len(page.Issues) > 0 && next < page.Total {
+		result.next = strconv.Itoa(next)
+	}
+	return result, response, nil
+}
+
+// toAPIIssue converte a issue da API v2 nos campos comuns às duas versões.
+func toAPIIssue(issue *models.IssueSchemeV2) *apiIssue {
+	converted := &models.IssueScheme{
+		ID:        issue.ID,
+		Key:       issue.Key,
+		Changelog: issue.Changelog,
+	}
+	var description string
+	if fields := issue.Fields; fields != nil {
+		converted.Fields = &models.IssueFieldsScheme{
+			Summary:        fields.Summary,
+			Status:         fields.Status,
+			Created:        fields.Created,
+			Assignee:       fields.Assignee,
+			Resolutiondate: fields.ResolutionDate,
+		}
+		description = fields.Description
+	}
+	return &apiIssue{IssueScheme: converted, description: parseWiki(description)}
+}
+
+// worklogs busca os worklogs da issue. O Server/Data Center ignora a
+// paginação e o filtro por data, devolvendo todos os worklogs.
+func (a *serverAPI) worklogs(
+	ctx context.Context, issueKey string, startAt, pageSize int,
+	since time.Time,
+) (*worklogPage, *models.ResponseScheme, error) {
+	page, response, err := a.client.Issue.Worklog.Issue(
+		ctx, issueKey, startAt, pageSize, int(since.UnixMilli()), nil,
+	)
+	if err != nil {
+		return nil, response, err
+	}
+	if page == nil {
+		return &worklogPage{}, response, nil
+	}
+
+	worklogs := make([]*apiWorklog, 0, len(page.Worklogs))
+	for _, worklog := range page.Worklogs {
+		if worklog == nil {
+			continue
+		}
+		worklogs = append(worklogs, &apiWorklog{
+			author:  worklog.Author,
+			started: worklog.Started,
+			seconds: worklog.TimeSpentSeconds,
+		})
+	}
+	total := page.Total
+	if total == 0 {
+		total = startAt + len(worklogs)
+	}
+	return &worklogPage{worklogs: worklogs, total: total}, response, nil
+}
+
+// myself retorna o usuário autenticado.
+func (a *serverAPI) myself(
+	ctx context.Context,
+) (*models.UserScheme, *models.ResponseScheme, error) {
+	return a.client.MySelf.Details(ctx, nil)
+}
+
+// searchUsers busca usuários pelo e-mail. O Server/Data Center usa o
+// parâmetro username, que também aceita o e-mail, no lugar de query.
+func (a *serverAPI) searchUsers(
+	ctx context.Context, email string, limit int,
+) ([]*models.UserScheme, *models.ResponseScheme, error) {
+	params := url.Values{}
+	params.Set("username", email)
+	params.Set("maxResults", strconv.Itoa(limit))
+
+	request, err := a.client.NewRequest(
+		ctx, http.MethodGet, "rest/api/2/user/search?"+params.Encode(), "", nil,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var users []*models.UserScheme
+	response, err := a.client.Call(request, &users)
+	if err != nil {
+		return nil, response, err
+	}
+	return users, response, nil
+}
+
+// permissions informa as permissões do usuário autenticado.
+func (a *serverAPI) permissions(
+	ctx context.Context, keys []string,
+) (map[string]bool, *models.ResponseScheme, error) {
+	return myPermissions(ctx, a.client, "2", keys)
+}
+
+// fields retorna os campos das issues.
+func (a *serverAPI) fields(
+	ctx context.Context,
+) ([]*models.IssueFieldScheme, *models.ResponseScheme, error) {
+	return a.client.Issue.Field.Gets(ctx)
+}
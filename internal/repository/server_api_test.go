@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alan-gomes1/jira-reporter/internal/config"
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+)
+
+// serverSearchRequest representa o payload enviado ao endpoint de busca da
+// API v2.
+type serverSearchRequest struct {
+	StartAt    int `json:"startAt"`
+	MaxResults int `json:"maxResults"`
+}
+
+// newFakeServerAPI simula os endpoints search e myself da API v2 do Jira
+// Data Center, devolvendo totalIssues issues paginadas por startAt. As
+// requisições de busca e o cabeçalho Authorization recebidos ficam
+// registrados.
+func newFakeServerAPI(
+	t *testing.T, totalIssues int,
+	requests *[]serverSearchRequest, authorization *string,
+) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/2/search", func(w http.ResponseWriter, r *http.Request) {
+		*authorization = r.Header.Get("Authorization")
+
+		var req serverSearchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		*requests = append(*requests, req)
+
+		end := min(req.StartAt+req.MaxResults, totalIssues)
+		issues := make([]map[string]any, 0, end-req.StartAt)
+		for i := req.StartAt; i < end; i++ {
+			issues = append(issues, map[string]any{
+				"key": fmt.Sprintf("PROJ-%d", i+1),
+				"fields": map[string]any{
+					"summary":     fmt.Sprintf("Issue %d", i+1),
+					"created":     "2025-01-10T10:00:00.000-0300",
+					"description": "h2. Contexto\n* *item* um\n* item dois",
+				},
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"startAt": req.StartAt,
+			"total":   totalIssues,
+			"issues":  issues,
+		})
+	})
+	mux.HandleFunc("/rest/api/2/myself", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"name":     "jsilva",
+			"timeZone": "UTC",
+		})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetchIssuesFromServerPaginatesByStartAt(t *testing.T) {
+	var (
+		requests      []serverSearchRequest
+		authorization string
+	)
+	server := newFakeServerAPI(t, 250, &requests, &authorization)
+
+	cfg := &config.Config{
+		JiraURL:        server.URL,
+		JiraToken:      "pat",
+		JiraDeployment: config.DeploymentServer,
+		JiraAuth:       config.AuthBearer,
+		SearchPageSize: 100,
+		SearchMaxPages: 10,
+	}
+	repo, err := NewJiraRepository(cfg, nil, nil)
+	if err != nil {
+		t.Fatalf("erro ao criar repositório: %v", err)
+	}
+
+	issues, err := repo.FetchIssues(testQuery())
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	if issues.Count() != 250 {
+		t.Errorf("esperado 250 issues, obtido %d", issues.Count())
+	}
+	if len(requests) != 3 {
+		t.Fatalf("esperado 3 páginas, obtido %d", len(requests))
+	}
+	for i, req := range requests {
+		if req.StartAt != i*100 {
+			t.Errorf(
+				"página %d: startAt esperado %d, obtido %d",
+				i+1, i*100, req.StartAt,
+			)
+		}
+	}
+	if authorization != "Bearer pat" {
+		t.Errorf("Authorization esperado %q, obtido %q", "Bearer pat", authorization)
+	}
+
+	description := issues.Items[0].Description
+	if len(description.Blocks) != 2 ||
+		description.Blocks[0].Type != model.BlockHeading ||
+		description.Blocks[1].Type != model.BlockBulletList {
+		t.Fatalf("descrição em wiki markup não convertida: %+v", description)
+	}
+}
+
+func TestParseWikiConvertsBlocksAndInlines(t *testing.T) {
+	text := "Texto com *negrito*, _itálico_ e {{código}}.\n" +
+		"Veja [a doc|https://exemplo.com] com [~jsilva].\n\n" +
+		"# primeiro\n## aninhado\n# segundo\n\n" +
+		"{code:java}\nint x = 1;\n{code}\n" +
+		"bq. citação"
+
+	blocks := parseWiki(text).Blocks
+	if len(blocks) != 4 {
+		t.Fatalf("esperado 4 blocos, obtido %d: %+v", len(blocks), blocks)
+	}
+
+	paragraph := blocks[0]
+	if paragraph.Type != model.BlockParagraph {
+		t.Fatalf("bloco 1: esperado parágrafo, obtido %s", paragraph.Type)
+	}
+	marks := map[string]func(model.Inline) bool{
+		"negrito": func(i model.Inline) bool { return i.Bold },
+		"itálico": func(i model.Inline) bool { return i.Italic },
+		"código":  func(i model.Inline) bool { return i.Code },
+		"a doc":   func(i model.Inline) bool { return i.Link == "https://exemplo.com" },
+		"@jsilva": func(i model.Inline) bool { return i.Mention },
+	}
+	breaks := 0
+	for _, inline := range paragraph.Inlines {
+		if inline.Break {
+			breaks++
+		}
+		if check, ok := marks[inline.Text]; ok && check(inline) {
+			delete(marks, inline.Text)
+		}
+	}
+	for text := range marks {
+		t.Errorf("inline %q não encontrado em %+v", text, paragraph.Inlines)
+	}
+	if breaks != 1 {
+		t.Errorf("esperada 1 quebra de linha entre as linhas, obtido %d", breaks)
+	}
+
+	list := blocks[1]
+	if list.Type != model.BlockOrderedList || len(list.Items) != 2 {
+		t.Fatalf("bloco 2: esperada lista numerada com 2 itens, obtido %+v", list)
+	}
+	if nested := list.Items[0].Blocks; len(nested) != 2 ||
+		nested[1].Type != model.BlockOrderedList {
+		t.Errorf("esperada lista aninhada no primeiro item, obtido %+v", nested)
+	}
+
+	code := blocks[2]
+	if code.Type != model.BlockCode || code.Language != "java" ||
+		code.Text != "int x = 1;" {
+		t.Errorf("bloco 3: código inesperado %+v", code)
+	}
+	if blocks[3].Type != model.BlockQuote {
+		t.Errorf("bloco 4: esperada citação, obtido %s", blocks[3].Type)
+	}
+}
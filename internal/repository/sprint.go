@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+)
+
+// agilePageSize é a quantidade de boards/sprints pedida por página.
+const agilePageSize = 50
+
+// FetchSprint resolve a sprint pelo id, nome ou "last" (última encerrada).
+func (r *jiraAPIRepository) FetchSprint(
+	board, sprint string,
+) (*model.Sprint, error) {
+	sprint = strings.TrimSpace(sprint)
+
+	// As datas da sprint definem os dias do período no fuso do relatório
+	if _, err := r.TimeZone(); err != nil {
+		return nil, err
+	}
+
+	// Sprint informada pelo id dispensa o board
+	if sprintID, err := strconv.Atoi(sprint); err == nil {
+		scheme, response, err := r.agile.Sprint.Get(context.Background(), sprintID)
+		if err != nil {
+			return nil, agileError("erro ao buscar a sprint "+sprint, response, err)
+		}
+		return r.toSprint(scheme)
+	}
+
+	if strings.TrimSpace(board) == "" {
+		return nil, fmt.Errorf(
+			"informe o board (--board) para buscar a sprint '%s'", sprint,
+		)
+	}
+	boardID, err := r.resolveBoardID(board)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.EqualFold(sprint, model.SprintLast) {
+		return r.lastClosedSprint(boardID)
+	}
+
+	sprints, err := r.boardSprints(boardID, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, scheme := range sprints {
+		if strings.EqualFold(scheme.Name, sprint) {
+			return r.toSprint(scheme)
+		}
+	}
+	return nil, fmt.Errorf(
+		"sprint '%s' não encontrada no board %d", sprint, boardID,
+	)
+}
+
+// resolveBoardID converte o board informado (id ou nome) em id.
+func (r *jiraAPIRepository) resolveBoardID(board string) (int, error) {
+	board = strings.TrimSpace(board)
+	if boardID, err := strconv.Atoi(board); err == nil {
+		return boardID, nil
+	}
+
+	options := &models.GetBoardsOptions{BoardName: board}
+	page, response, err := r.agile.Board.Gets(
+		context.Background(), options, 0, agilePageSize,
+	)
+	if err != nil {
+		return 0, agileError("erro ao buscar o board "+board, response, err)
+	}
+	if page == nil || len(page.Values) == 0 {
+		return 0, fmt.Errorf("board '%s' não encontrado", board)
+	}
+
+	// A busca por nome é parcial: prefere o board com o nome exato
+	names := make([]string, 0, len(page.Values))
+	for _, candidate := range page.Values {
+		if strings.EqualFold(candidate.Name, board) {
+			return candidate.ID, nil
+		}
+		names = append(names, candidate.Name)
+	}
+	if len(page.Values) == 1 {
+		return page.Values[0].ID, nil
+	}
+	return 0, fmt.Errorf(
+		"board '%s' é ambíguo, encontrados: %s", board, strings.Join(names, ", "),
+	)
+}
+
+// lastClosedSprint retorna a sprint encerrada mais recentemente no board.
+func (r *jiraAPIRepository) lastClosedSprint(boardID int) (*model.Sprint, error) {
+	sprints, err := r.boardSprints(boardID, []string{"closed"})
+	if err != nil {
+		return nil, err
+	}
+
+	var last *models.SprintScheme
+	for _, scheme := range sprints {
+		if last == nil || scheme.CompleteDate.After(last.CompleteDate) {
+			last = scheme
+		}
+	}
+	if last == nil {
+		return nil, fmt.Errorf("nenhuma sprint encerrada no board %d", boardID)
+	}
+	return r.toSprint(last)
+}
+
+// boardSprints percorre todas as páginas de sprints do board, opcionalmente
+// filtrando pelos estados informados.
+func (r *jiraAPIRepository) boardSprints(
+	boardID int, states []string,
+) ([]*models.SprintScheme, error) {
+	var sprints []*models.SprintScheme
+	startAt := 0
+	for {
+		page, response, err := r.agile.Board.Sprints(
+			context.Background(), boardID, startAt, agilePageSize, states,
+		)
+		if err != nil {
+			return nil, agileError(
+				fmt.Sprintf("erro ao buscar as sprints do board %d", boardID),
+				response, err,
+			)
+		}
+		if page == nil || len(page.Values) == 0 {
+			return sprints, nil
+		}
+
+		for _, scheme := range page.Values {
+			sprint := models.SprintScheme(*scheme)
+			sprints = append(sprints, &sprint)
+		}
+
+		startAt += len(page.Values)
+		if page.IsLast {
+			return sprints, nil
+		}
+	}
+}
+
+// toSprint converte a sprint da API no modelo da aplicação. Sprints
+// encerradas usam a data de conclusão como fim do período. As datas são
+// convertidas para o fuso horário do relatório.
+func (r *jiraAPIRepository) toSprint(
+	scheme *models.SprintScheme,
+) (*model.Sprint, error) {
+	if scheme.StartDate.IsZero() {
+		return nil, fmt.Errorf("a sprint '%s' ainda não foi iniciada", scheme.Name)
+	}
+
+	end := scheme.EndDate
+	if !scheme.CompleteDate.IsZero() {
+		end = scheme.CompleteDate
+	}
+
+	return &model.Sprint{
+		ID:      scheme.ID,
+		BoardID: scheme.OriginBoardID,
+		Name:    scheme.Name,
+		Goal:    scheme.Goal,
+		State:   scheme.State,
+		Start:   scheme.StartDate.In(r.location),
+		End:     end.In(r.location),
+	}, nil
+}
+
+// agileError monta o erro de uma chamada à API Agile, incluindo o status
+// HTTP quando disponível.
+func agileError(
+	message string, response *models.ResponseScheme, err error,
+) error {
+	if response != nil {
+		return fmt.Errorf("%s: %w - status: %s", message, err, response.Status)
+	}
+	return fmt.Errorf("%s: %w", message, err)
+}
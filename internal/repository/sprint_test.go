@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeBoard é um board devolvido pela API Agile simulada.
+type fakeBoard struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// fakeSprint é uma sprint devolvida pela API Agile simulada.
+type fakeSprint struct {
+	ID            int        `json:"id"`
+	State         string     `json:"state"`
+	Name          string     `json:"name"`
+	StartDate     time.Time  `json:"startDate"`
+	EndDate       time.Time  `json:"endDate"`
+	CompleteDate  *time.Time `json:"completeDate,omitempty"`
+	OriginBoardID int        `json:"originBoardId"`
+}
+
+// fakeAgileServer simula os endpoints de boards e sprints da API Agile.
+// Os estados pedidos na listagem de sprints ficam registrados em states.
+type fakeAgileServer struct {
+	*httptest.Server
+	boards  []fakeBoard
+	sprints map[int][]fakeSprint // Sprints por board, na ordem da API
+	states  []string
+}
+
+// newFakeAgileServer cria os boards "Time ABC" (1), "Time ABC Mobile" (2)
+// e "Time XYZ" (3). O board 1 tem 60 sprints encerradas, devolvidas da mais
+// recente para a mais antiga em duas páginas, e uma sprint ativa.
+func newFakeAgileServer(t *testing.T) *fakeAgileServer {
+	t.Helper()
+
+	fake := &fakeAgileServer{
+		boards: []fakeBoard{
+			{1, "Time ABC"}, {2, "Time ABC Mobile"}, {3, "Time XYZ"},
+		},
+		sprints: map[int][]fakeSprint{},
+	}
+	first := time.Date(2024, time.January, 1, 13, 0, 0, 0, time.UTC)
+	for i := 60; i >= 1; i-- {
+		start := first.AddDate(0, 0, (i-1)*14)
+		complete := start.AddDate(0, 0, 13).Add(2 * time.Hour)
+		fake.sprints[1] = append(fake.sprints[1], fakeSprint{
+			ID: i, State: "closed", Name: fmt.Sprintf("Sprint %d", i),
+			StartDate: start, EndDate: start.AddDate(0, 0, 14),
+			CompleteDate: &complete, OriginBoardID: 1,
+		})
+	}
+	activeStart := first.AddDate(0, 0, 60*14)
+	fake.sprints[1] = append(fake.sprints[1], fakeSprint{
+		ID: 61, State: "active", Name: "Sprint 61",
+		StartDate: activeStart, EndDate: activeStart.AddDate(0, 0, 14),
+		OriginBoardID: 1,
+	})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/myself", handleMyself("UTC"))
+	mux.HandleFunc("/rest/agile/1.0/board", fake.handleBoards)
+	mux.HandleFunc("/rest/agile/1.0/board/{id}/sprint", fake.handleBoardSprints)
+	mux.HandleFunc("/rest/agile/1.0/sprint/{id}", fake.handleSprint)
+	fake.Server = httptest.NewServer(mux)
+	t.Cleanup(fake.Close)
+
+	return fake
+}
+
+// handleBoards busca os boards cujo nome contém o texto informado, como a
+// API Agile.
+func (f *fakeAgileServer) handleBoards(w http.ResponseWriter, r *http.Request) {
+	name := strings.ToLower(r.URL.Query().Get("name"))
+	boards := []fakeBoard{}
+	for _, board := range f.boards {
+		if strings.Contains(strings.ToLower(board.Name), name) {
+			boards = append(boards, board)
+		}
+	}
+	writeJSON(w, map[string]any{
+		"isLast": true, "total": len(boards), "values": boards,
+	})
+}
+
+// handleBoardSprints lista as sprints do board paginadas por startAt e
+// filtradas pelos estados informados.
+func (f *fakeAgileServer) handleBoardSprints(w http.ResponseWriter, r *http.Request) {
+	boardID, _ := strconv.Atoi(r.PathValue("id"))
+	query := r.URL.Query()
+	f.states = append(f.states, query.Get("state"))
+
+	var sprints []fakeSprint
+	for _, sprint := range f.sprints[boardID] {
+		states := query.Get("state")
+		if states == "" || slices.Contains(strings.Split(states, ","), sprint.State) {
+			sprints = append(sprints, sprint)
+		}
+	}
+
+	startAt, _ := strconv.Atoi(query.Get("startAt"))
+	maxResults, _ := strconv.Atoi(query.Get("maxResults"))
+	startAt = min(startAt, len(sprints))
+	end := min(startAt+maxResults, len(sprints))
+	writeJSON(w, map[string]any{
+		"startAt": startAt,
+		"isLast":  end == len(sprints),
+		"values":  sprints[startAt:end],
+	})
+}
+
+// handleSprint devolve a sprint pelo id.
+func (f *fakeAgileServer) handleSprint(w http.ResponseWriter, r *http.Request) {
+	sprintID, _ := strconv.Atoi(r.PathValue("id"))
+	for _, sprints := range f.sprints {
+		for _, sprint := range sprints {
+			if sprint.ID == sprintID {
+				writeJSON(w, sprint)
+				return
+			}
+		}
+	}
+	http.Error(w, `{"errorMessages":["sprint não encontrada"]}`, http.StatusNotFound)
+}
+
+// writeJSON escreve a resposta JSON da API simulada.
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(value)
+}
+
+func TestFetchSprintResolvesBoardAndSprint(t *testing.T) {
+	fake := newFakeAgileServer(t)
+	repo := newTestRepository(t, fake.URL, 100, 10)
+
+	tests := []struct {
+		name          string
+		board, sprint string
+		expectedID    int
+		err           string // Trecho esperado do erro (vazio quando válido)
+	}{
+		{name: "nome exato do board entre vários", board: "time abc", sprint: "sprint 12", expectedID: 12},
+		{name: "nome parcial de um único board", board: "Mobile", sprint: "Sprint 3", err: "sprint 'Sprint 3' não encontrada no board 2"},
+		{name: "board ambíguo", board: "Time", sprint: "Sprint 1", err: "board 'Time' é ambíguo, encontrados: Time ABC, Time ABC Mobile, Time XYZ"},
+		{name: "board inexistente", board: "Outro", sprint: "Sprint 1", err: "board 'Outro' não encontrado"},
+		{name: "board pelo id", board: "1", sprint: "Sprint 59", expectedID: 59},
+		{name: "última encerrada", board: "Time ABC", sprint: "last", expectedID: 60},
+		{name: "sprint inexistente", board: "Time ABC", sprint: "Sprint 99", err: "sprint 'Sprint 99' não encontrada no board 1"},
+		{name: "sprint pelo id sem board", sprint: "61", expectedID: 61},
+		{name: "id de sprint inexistente", sprint: "999", err: "erro ao buscar a sprint 999"},
+		{name: "nome sem board", sprint: "Sprint 1", err: "informe o board (--board)"},
+	}
+	for _, tt := range tests {
+		sprint, err := repo.FetchSprint(tt.board, tt.sprint)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%s: esperado erro contendo %q, obtido %v", tt.name, tt.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: erro inesperado: %v", tt.name, err)
+			continue
+		}
+		if sprint.ID != tt.expectedID {
+			t.Errorf("%s: sprint esperada %d, obtido %d", tt.name, tt.expectedID, sprint.ID)
+		}
+	}
+}
+
+func TestFetchSprintLastUsesCompleteDateAcrossPages(t *testing.T) {
+	fake := newFakeAgileServer(t)
+	repo := newTestRepository(t, fake.URL, 100, 10)
+
+	sprint, err := repo.FetchSprint("1", "LAST")
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	// A sprint 60 é a primeira da primeira página: as demais páginas
+	// também precisam ser percorridas sem substituí-la
+	if sprint.ID != 60 || sprint.Name != "Sprint 60" {
+		t.Errorf("esperada a Sprint 60, obtido %s (%d)", sprint.Name, sprint.ID)
+	}
+	expectedEnd := *fake.sprints[1][0].CompleteDate
+	if !sprint.End.Equal(expectedEnd) {
+		t.Errorf("fim esperado na conclusão %s, obtido %s", expectedEnd, sprint.End)
+	}
+	if len(fake.states) != 2 || fake.states[0] != "closed" || fake.states[1] != "closed" {
+		t.Errorf("esperadas 2 páginas de sprints encerradas, obtido %v", fake.states)
+	}
+}
+
+func TestFetchSprintRejectsSprintNotStarted(t *testing.T) {
+	fake := newFakeAgileServer(t)
+	fake.sprints[1] = append(fake.sprints[1], fakeSprint{
+		ID: 62, State: "future", Name: "Sprint 62", OriginBoardID: 1,
+	})
+	repo := newTestRepository(t, fake.URL, 100, 10)
+
+	_, err := repo.FetchSprint("Time ABC", "Sprint 62")
+	if err == nil || !strings.Contains(err.Error(), "ainda não foi iniciada") {
+		t.Errorf("esperado erro de sprint não iniciada, obtido %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeZone retorna o fuso horário do relatório: o configurado em TIMEZONE
+// ou, quando vazio, o do perfil do usuário autenticado no Jira.
+func (r *jiraAPIRepository) TimeZone() (*time.Location, error) {
+	if r.location != nil {
+		return r.location, nil
+	}
+
+	if r.config.TimeZone != "" {
+		location, err := time.LoadLocation(r.config.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("TIMEZONE inválido: %w", err)
+		}
+		r.location = location
+		return location, nil
+	}
+
+	location, err := r.profileTimeZone()
+	if err != nil {
+		return nil, err
+	}
+	r.location = location
+	return location, nil
+}
+
+// profileTimeZone retorna o fuso horário do perfil do usuário autenticado,
+// no qual o Jira interpreta as datas da JQL. Quando o Jira não informa o
+// fuso (ex: oculto pela privacidade do perfil), usa o fuso local.
+func (r *jiraAPIRepository) profileTimeZone() (*time.Location, error) {
+	if r.profileLocation != nil {
+		return r.profileLocation, nil
+	}
+
+	user, err := r.currentUser()
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TimeZone == "" {
+		fmt.Fprintf(r.progress,
+			"Fuso horário do perfil no Jira indisponível, usando o fuso "+
+				"local (%s)\n", time.Local,
+		)
+		r.profileLocation = time.Local
+		return r.profileLocation, nil
+	}
+
+	location, err := time.LoadLocation(user.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"fuso horário do perfil no Jira inválido (%s): %w",
+			user.TimeZone, err,
+		)
+	}
+	r.profileLocation = location
+	return location, nil
+}
+
+// periodBounds retorna o início do primeiro dia e o fim (exclusivo) do
+// último dia do período, no fuso horário do relatório. As datas da consulta
+// representam dias do calendário, sem fuso.
+func (r *jiraAPIRepository) periodBounds(query IssueQuery) (time.Time, time.Time) {
+	start := time.Date(
+		query.StartDate.Year(), query.StartDate.Month(), query.StartDate.Day(),
+		0, 0, 0, 0, r.location,
+	)
+	end := time.Date(
+		query.EndDate.Year(), query.EndDate.Month(), query.EndDate.Day()+1,
+		0, 0, 0, 0, r.location,
+	)
+	return start, end
+}
+
+// jqlBounds retorna o primeiro e o último minuto do período no fuso do
+// perfil do usuário no Jira, como a JQL espera.
+func (r *jiraAPIRepository) jqlBounds(query IssueQuery) (time.Time, time.Time) {
+	start, end := r.periodBounds(query)
+	return start.In(r.profileLocation), end.Add(-time.Minute).In(r.profileLocation)
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ctreminiom/go-atlassian/v2/pkg/infra/models"
+)
+
+// userSearchLimit é a quantidade máxima de usuários retornados na busca.
+const userSearchLimit = 10
+
+// FindAccountID busca o identificador do usuário com o e-mail informado: o
+// accountId no Cloud ou o nome no Server/Data Center.
+func (r *jiraAPIRepository) FindAccountID(email string) (string, error) {
+	users, response, err := r.api.searchUsers(
+		context.Background(), email, userSearchLimit,
+	)
+	if err != nil {
+		if response != nil {
+			return "", fmt.Errorf(
+				"erro ao buscar o usuário %s: %w - status: %s",
+				email, err, response.Status,
+			)
+		}
+		return "", fmt.Errorf("erro ao buscar o usuário %s: %w", email, err)
+	}
+
+	// O e-mail pode estar oculto pelas configurações de privacidade do
+	// usuário; nesse caso, aceita o resultado apenas se ele for único
+	for _, user := range users {
+		if strings.EqualFold(user.EmailAddress, email) {
+			return userID(user), nil
+		}
+	}
+	if len(users) == 1 {
+		return userID(users[0]), nil
+	}
+	if len(users) == 0 {
+		return "", fmt.Errorf("nenhum usuário encontrado com o e-mail %s", email)
+	}
+	return "", fmt.Errorf(
+		"e-mail %s corresponde a %d usuários, informe o accountId",
+		email, len(users),
+	)
+}
+
+// Authenticate verifica as credenciais na API myself e retorna o nome do
+// usuário autenticado.
+func (r *jiraAPIRepository) Authenticate() (string, error) {
+	user, err := r.currentUser()
+	if err != nil {
+		return "", err
+	}
+	return user.DisplayName, nil
+}
+
+// currentUser retorna o usuário autenticado, consultando a API myself apenas
+// na primeira chamada.
+func (r *jiraAPIRepository) currentUser() (*models.UserScheme, error) {
+	if r.user != nil {
+		return r.user, nil
+	}
+
+	user, response, err := r.api.myself(context.Background())
+	if err != nil {
+		if response != nil {
+			return nil, fmt.Errorf(
+				"erro ao consultar o usuário atual: %w - status: %s",
+				err, response.Status,
+			)
+		}
+		return nil, fmt.Errorf("erro ao consultar o usuário atual: %w", err)
+	}
+
+	r.user = user
+	return user, nil
+}
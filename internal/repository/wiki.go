@@ -0,0 +1,363 @@
+package repository
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+)
+
+// Padrões das linhas especiais do wiki markup do Jira Server/Data Center.
+var (
+	wikiHeadingPattern = regexp.MustCompile(`^h([1-6])\.\s+(.*)$`)
+	wikiListPattern    = regexp.MustCompile(`^([*#]+|-)\s+(.*)$`)
+	wikiBlockPattern   = regexp.MustCompile(`^\{(code|noformat|quote)(?::([^}]*))?\}(.*)$`)
+)
+
+// wikiMarks são os delimitadores de formatação inline do wiki markup.
+var wikiMarks = map[rune]func(*model.Inline){
+	'*': func(inline *model.Inline) { inline.Bold = true },
+	'_': func(inline *model.Inline) { inline.Italic = true },
+	'+': func(inline *model.Inline) { inline.Underline = true },
+	'-': func(inline *model.Inline) { inline.Strike = true },
+}
+
+// parseWiki converte a descrição em wiki markup (API v2 do Jira
+// Server/Data Center) para a descrição estruturada do domínio, com os
+// mesmos blocos gerados a partir do ADF no Jira Cloud.
+func parseWiki(text string) model.Description {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	if strings.TrimSpace(text) == "" {
+		return model.Description{}
+	}
+	return model.Description{Blocks: parseWikiBlocks(strings.Split(text, "\n"))}
+}
+
+// parseWikiBlocks converte as linhas em blocos. Linhas consecutivas de
+// texto formam um parágrafo, com quebras de linha forçadas entre elas.
+func parseWikiBlocks(lines []string) []model.Block {
+	var (
+		blocks    []model.Block
+		paragraph []string
+	)
+	flush := func() {
+		if len(paragraph) > 0 {
+			blocks = append(blocks, wikiParagraph(paragraph))
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+
+		switch {
+		case line == "":
+			flush()
+		case wikiBlockPattern.MatchString(line):
+			flush()
+			var block model.Block
+			block, i = parseWikiDelimited(lines, i)
+			blocks = append(blocks, block)
+		case wikiHeadingPattern.MatchString(line):
+			flush()
+			match := wikiHeadingPattern.FindStringSubmatch(line)
+			blocks = append(blocks, model.Block{
+				Type:    model.BlockHeading,
+				Level:   int(match[1][0] - '0'),
+				Inlines: parseWikiInlines(match[2]),
+			})
+		case strings.HasPrefix(line, "bq. "):
+			flush()
+			blocks = append(blocks, model.Block{
+				Type:     model.BlockQuote,
+				Children: []model.Block{wikiParagraph([]string{line[4:]})},
+			})
+		case strings.Trim(line, "-") == "" && len(line) >= 4:
+			flush()
+			blocks = append(blocks, model.Block{Type: model.BlockRule})
+		case wikiListPattern.MatchString(line):
+			flush()
+			end := i
+			for end < len(lines) && wikiListPattern.MatchString(strings.TrimSpace(lines[end])) {
+				end++
+			}
+			blocks = append(blocks, parseWikiList(lines[i:end], 1)...)
+			i = end - 1
+		case strings.HasPrefix(line, "|"):
+			flush()
+			blocks = append(blocks, wikiTableRow(line))
+		default:
+			paragraph = append(paragraph, line)
+		}
+	}
+	flush()
+	return blocks
+}
+
+// parseWikiDelimited converte um bloco {code}, {noformat} ou {quote} que
+// começa na linha informada, retornando o bloco e a última linha dele.
+func parseWikiDelimited(lines []string, start int) (model.Block, int) {
+	match := wikiBlockPattern.FindStringSubmatch(strings.TrimSpace(lines[start]))
+	name, params, rest := match[1], match[2], match[3]
+	closing := "{" + name + "}"
+
+	var content []string
+	end := len(lines) - 1
+	if before, _, found := strings.Cut(rest, closing); found {
+		// Bloco aberto e fechado na mesma linha
+		content = append(content, before)
+		end = start
+	} else {
+		if rest != "" {
+			content = append(content, rest)
+		}
+		for i := start + 1; i < len(lines); i++ {
+			if before, _, found := strings.Cut(lines[i], closing); found {
+				if strings.TrimSpace(before) != "" {
+					content = append(content, before)
+				}
+				end = i
+				break
+			}
+			content = append(content, lines[i])
+		}
+	}
+
+	if name == "quote" {
+		return model.Block{
+			Type: model.BlockQuote, Children: parseWikiBlocks(content),
+		}, end
+	}
+	return model.Block{
+		Type:     model.BlockCode,
+		Language: wikiCodeLanguage(params),
+		Text:     strings.Join(content, "\n"),
+	}, end
+}
+
+// wikiCodeLanguage extrai a linguagem dos parâmetros do bloco de código
+// (ex: java ou language=java|title=Exemplo).
+func wikiCodeLanguage(params string) string {
+	for _, param := range strings.Split(params, "|") {
+		key, value, found := strings.Cut(param, "=")
+		if !found {
+			if !strings.Contains(param, ":") {
+				return strings.TrimSpace(param)
+			}
+			continue
+		}
+		if strings.TrimSpace(key) == "language" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// parseWikiList converte linhas de lista (* ou - para marcadores, # para
+// numeração) a partir da profundidade informada. Linhas mais profundas
+// formam listas aninhadas no item anterior.
+func parseWikiList(lines []string, depth int) []model.Block {
+	var blocks []model.Block
+	for i := 0; i < len(lines); {
+		markers, text := wikiListItem(lines[i])
+		listType := model.BlockBulletList
+		if strings.HasSuffix(markers, "#") {
+			listType = model.BlockOrderedList
+		}
+
+		list := model.Block{Type: listType}
+		for i < len(lines) {
+			markers, text = wikiListItem(lines[i])
+			itemType := model.BlockBulletList
+			if strings.HasSuffix(markers, "#") {
+				itemType = model.BlockOrderedList
+			}
+			if len(markers) == depth && itemType != listType {
+				break
+			}
+
+			item := model.ListItem{Blocks: []model.Block{
+				wikiParagraph([]string{text}),
+			}}
+			i++
+
+			// Itens mais profundos pertencem ao item atual
+			end := i
+			for end < len(lines) {
+				nested, _ := wikiListItem(lines[end])
+				if len(nested) <= depth {
+					break
+				}
+				end++
+			}
+			if end > i {
+				item.Blocks = append(item.Blocks, parseWikiList(lines[i:end], depth+1)...)
+				i = end
+			}
+			list.Items = append(list.Items, item)
+		}
+		blocks = append(blocks, list)
+	}
+	return blocks
+}
+
+// wikiListItem separa os marcadores e o texto de uma linha de lista. O
+// marcador "-" equivale a "*".
+func wikiListItem(line string) (string, string) {
+	match := wikiListPattern.FindStringSubmatch(strings.TrimSpace(line))
+	if match == nil {
+		return "", line
+	}
+	markers := match[1]
+	if markers == "-" {
+		markers = "*"
+	}
+	return markers, match[2]
+}
+
+// wikiParagraph converte linhas de texto em um parágrafo.
+func wikiParagraph(lines []string) model.Block {
+	var inlines []model.Inline
+	for i, line := range lines {
+		if i > 0 {
+			inlines = append(inlines, model.Inline{Break: true})
+		}
+		inlines = append(inlines, parseWikiInlines(line)...)
+	}
+	return model.Block{Type: model.BlockParagraph, Inlines: inlines}
+}
+
+// wikiTableRow converte uma linha de tabela em um parágrafo, com as células
+// separadas por " | ", como as tabelas do ADF.
+func wikiTableRow(line string) model.Block {
+	header := strings.HasPrefix(line, "||")
+	separator := "|"
+	if header {
+		separator = "||"
+	}
+
+	var inlines []model.Inline
+	for _, cell := range strings.Split(strings.Trim(line, "|"), separator) {
+		if len(inlines) > 0 {
+			inlines = append(inlines, model.Inline{Text: " | "})
+		}
+		inlines = append(inlines, model.Inline{
+			Text: strings.TrimSpace(cell), Bold: header,
+		})
+	}
+	return model.Block{Type: model.BlockParagraph, Inlines: inlines}
+}
+
+// parseWikiInlines converte o texto de uma linha: formatação (*negrito*,
+// _itálico_, +sublinhado+, -tachado-), {{código}}, links [texto|url],
+// menções [~usuário] e quebras de linha forçadas (\\).
+func parseWikiInlines(text string) []model.Inline {
+	var (
+		inlines []model.Inline
+		plain   strings.Builder
+	)
+	flush := func() {
+		if plain.Len() > 0 {
+			inlines = append(inlines, model.Inline{Text: plain.String()})
+			plain.Reset()
+		}
+	}
+
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		rest := string(runes[i:])
+
+		switch {
+		case strings.HasPrefix(rest, `\\`):
+			flush()
+			inlines = append(inlines, model.Inline{Break: true})
+			i++
+		case runes[i] == '\\' && i+1 < len(runes):
+			plain.WriteRune(runes[i+1])
+			i++
+		case strings.HasPrefix(rest, "{{"):
+			code, _, found := strings.Cut(rest[2:], "}}")
+			if !found {
+				plain.WriteRune(runes[i])
+				continue
+			}
+			flush()
+			inlines = append(inlines, model.Inline{Text: code, Code: true})
+			i += len([]rune(code)) + 3
+		case runes[i] == '[':
+			content, _, found := strings.Cut(rest[1:], "]")
+			if !found {
+				plain.WriteRune(runes[i])
+				continue
+			}
+			flush()
+			inlines = append(inlines, wikiLink(content))
+			i += len([]rune(content)) + 1
+		case wikiMarks[runes[i]] != nil && wikiMarkOpens(runes, i):
+			end := wikiMarkEnd(runes, i)
+			if end < 0 {
+				plain.WriteRune(runes[i])
+				continue
+			}
+			flush()
+			mark := wikiMarks[runes[i]]
+			for _, inline := range parseWikiInlines(string(runes[i+1 : end])) {
+				mark(&inline)
+				inlines = append(inlines, inline)
+			}
+			i = end
+		default:
+			plain.WriteRune(runes[i])
+		}
+	}
+	flush()
+	return inlines
+}
+
+// wikiLink converte o conteúdo entre colchetes: menção (~usuário), anexo
+// (^arquivo), link com texto (texto|url) ou apenas a url.
+func wikiLink(content string) model.Inline {
+	switch {
+	case strings.HasPrefix(content, "~"):
+		return model.Inline{Text: "@" + content[1:], Mention: true}
+	case strings.HasPrefix(content, "^"):
+		return model.Inline{Text: content[1:]}
+	}
+
+	label, target, found := strings.Cut(content, "|")
+	if !found {
+		target = content
+	}
+	return model.Inline{Text: label, Link: strings.TrimSpace(target)}
+}
+
+// wikiMarkOpens verifica se o delimitador na posição abre uma formatação:
+// no início ou após um caractere que não é letra nem número, e seguido de
+// um caractere que não é espaço.
+func wikiMarkOpens(runes []rune, i int) bool {
+	if i+1 >= len(runes) || unicode.IsSpace(runes[i+1]) {
+		return false
+	}
+	return i == 0 || !isWikiWordRune(runes[i-1])
+}
+
+// wikiMarkEnd retorna a posição do delimitador que fecha a formatação
+// aberta na posição informada, ou -1 quando não há.
+func wikiMarkEnd(runes []rune, start int) int {
+	mark := runes[start]
+	for i := start + 2; i < len(runes); i++ {
+		if runes[i] != mark || unicode.IsSpace(runes[i-1]) {
+			continue
+		}
+		if i+1 == len(runes) || !isWikiWordRune(runes[i+1]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// isWikiWordRune verifica se o caractere faz parte de uma palavra.
+func isWikiWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
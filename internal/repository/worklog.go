@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+)
+
+// worklogPageSize é a quantidade de worklogs pedida por página.
+const worklogPageSize = 100
+
+// attachWorklogs busca os worklogs do usuário da consulta em cada issue da
+// coleção e preenche o tempo registrado por dia dentro do período.
+func (r *jiraAPIRepository) attachWorklogs(
+	collection *model.IssueCollection, query IssueQuery,
+) error {
+	accountID := query.Assignee
+	if accountID == "" {
+		var err error
+		if accountID, err = r.currentAccountID(); err != nil {
+			return err
+		}
+	}
+
+	for i := range collection.Items {
+		timeSpent, err := r.fetchTimeSpent(
+			collection.Items[i].Key, accountID, query,
+		)
+		if err != nil {
+			return err
+		}
+		collection.Items[i].TimeSpent = timeSpent
+	}
+	return nil
+}
+
+// fetchTimeSpent percorre os worklogs da issue e soma, por dia, o tempo
+// registrado pelo usuário dentro do período. Os limites do período e os
+// dias dos worklogs seguem o fuso horário do relatório.
+func (r *jiraAPIRepository) fetchTimeSpent(
+	issueKey, accountID string, query IssueQuery,
+) ([]model.DailyTime, error) {
+	periodStart, periodEnd := r.periodBounds(query)
+
+	var days []model.DailyTime
+	startAt := 0
+	for {
+		page, response, err := r.api.worklogs(
+			context.Background(), issueKey, startAt, worklogPageSize,
+			periodStart,
+		)
+		if err != nil {
+			if response != nil {
+				return nil, fmt.Errorf(
+					"erro ao buscar worklogs da issue %s: %w - status: %s",
+					issueKey, err, response.Status,
+				)
+			}
+			return nil, fmt.Errorf(
+				"erro ao buscar worklogs da issue %s: %w", issueKey, err,
+			)
+		}
+		if len(page.worklogs) == 0 {
+			return days, nil
+		}
+
+		for _, worklog := range page.worklogs {
+			author := worklog.author
+			if author == nil || !sameUser(
+				accountID, author.AccountID, author.Name, author.Key,
+			) {
+				continue
+			}
+
+			started := r.parseJiraTime(worklog.started)
+			if started.IsZero() {
+				continue
+			}
+			if started.Before(periodStart) || !started.Before(periodEnd) {
+				continue
+			}
+			days = model.AddTimeSpent(days, started, worklog.seconds)
+		}
+
+		startAt += len(page.worklogs)
+		if startAt >= page.total {
+			return days, nil
+		}
+	}
+}
+
+// currentAccountID retorna o identificador do usuário autenticado: o
+// accountId no Cloud ou o nome no Server/Data Center.
+func (r *jiraAPIRepository) currentAccountID() (string, error) {
+	user, err := r.currentUser()
+	if err != nil {
+		return "", err
+	}
+	return userID(user), nil
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testAccountID é o accountId informado pelo endpoint myself dos testes.
+const testAccountID = "5b10ac8d82e05b22cc7d4ef5"
+
+// fakeWorklog é um worklog devolvido pelo endpoint de worklogs simulado.
+type fakeWorklog struct {
+	author  string
+	started string
+	seconds int
+}
+
+// newFakeWorklogServer simula a busca de issues, o endpoint myself e o
+// endpoint de worklogs, paginado por startAt e maxResults. Os parâmetros
+// startedAfter recebidos ficam registrados.
+func newFakeWorklogServer(
+	t *testing.T, worklogs map[string][]fakeWorklog, startedAfter *[]string,
+) *httptest.Server {
+	t.Helper()
+
+	search := &fakeSearchServer{totalIssues: len(worklogs)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/search/jql", search.handleSearch)
+	mux.HandleFunc("/rest/api/3/myself", handleMyself("UTC"))
+	mux.HandleFunc("/rest/api/3/issue/{key}/worklog", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		*startedAfter = append(*startedAfter, query.Get("startedAfter"))
+		startAt, _ := strconv.Atoi(query.Get("startAt"))
+		maxResults, _ := strconv.Atoi(query.Get("maxResults"))
+
+		all := worklogs[r.PathValue("key")]
+		end := min(startAt+maxResults, len(all))
+		page := make([]map[string]any, 0, end-startAt)
+		for _, worklog := range all[startAt:end] {
+			page = append(page, map[string]any{
+				"author":           map[string]any{"accountId": worklog.author},
+				"started":          worklog.started,
+				"timeSpentSeconds": worklog.seconds,
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"startAt":    startAt,
+			"maxResults": maxResults,
+			"total":      len(all),
+			"worklogs":   page,
+		})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetchIssuesSumsWorklogsByDay(t *testing.T) {
+	worklogs := map[string][]fakeWorklog{
+		"PROJ-1": {
+			{testAccountID, "2025-01-06T09:00:00.000+0000", 3600},
+			{testAccountID, "2025-01-06T14:00:00.000+0000", 1800},
+			{"outro-usuario", "2025-01-07T09:00:00.000+0000", 7200},
+			{testAccountID, "2024-12-31T23:00:00.000+0000", 600},
+			{testAccountID, "2025-02-01T00:00:00.000+0000", 600},
+		},
+		"PROJ-2": nil,
+	}
+	// Mais worklogs do que cabem em uma página
+	for i := 0; i < worklogPageSize; i++ {
+		worklogs["PROJ-1"] = append(worklogs["PROJ-1"], fakeWorklog{
+			testAccountID, "2025-01-20T10:00:00.000+0000", 60,
+		})
+	}
+
+	var startedAfter []string
+	server := newFakeWorklogServer(t, worklogs, &startedAfter)
+	repo := newTestRepository(t, server.URL, 100, 10)
+
+	query := testQuery()
+	query.Worklogs = true
+	issues, err := repo.FetchIssues(query)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	timeSpent := map[string]map[string]int{}
+	for _, issue := range issues.Items {
+		days := map[string]int{}
+		for _, day := range issue.TimeSpent {
+			days[day.Date.Format(time.DateOnly)] = day.Seconds
+		}
+		timeSpent[issue.Key] = days
+	}
+
+	expected := map[string]int{"2025-01-06": 5400, "2025-01-20": 6000}
+	if len(timeSpent["PROJ-1"]) != len(expected) {
+		t.Errorf("PROJ-1: esperados os dias %v, obtido %v", expected, timeSpent["PROJ-1"])
+	}
+	for day, seconds := range expected {
+		if timeSpent["PROJ-1"][day] != seconds {
+			t.Errorf(
+				"PROJ-1 em %s: esperado %ds, obtido %ds",
+				day, seconds, timeSpent["PROJ-1"][day],
+			)
+		}
+	}
+	if len(timeSpent["PROJ-2"]) != 0 {
+		t.Errorf("PROJ-2: esperado nenhum registro, obtido %v", timeSpent["PROJ-2"])
+	}
+
+	// PROJ-1 tem duas páginas de worklogs e PROJ-2, uma
+	if len(startedAfter) != 3 {
+		t.Fatalf("esperadas 3 requisições de worklogs, obtido %d", len(startedAfter))
+	}
+	periodStart := strconv.FormatInt(query.StartDate.UnixMilli(), 10)
+	if strings.Join(startedAfter, ",") != strings.Repeat(periodStart+",", 2)+periodStart {
+		t.Errorf("startedAfter esperado %s, obtido %v", periodStart, startedAfter)
+	}
+}
@@ -3,9 +3,26 @@ package service
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+)
+
+// Expressões de período aceitas por ParsePeriod.
+var (
+	isoWeekPattern  = regexp.MustCompile(`^(\d{4})-?W(\d{1,2})$`)
+	quarterPattern  = regexp.MustCompile(`^Q([1-4])/(\d{4})$`)
+	lastDaysPattern = regexp.MustCompile(
+		`^(?:last|[úu]ltimos)\s+(\d+)\s+(?:days?|dias?)$`,
+	)
 )
 
+// rangeDateLayouts são os formatos aceitos para --from e --to.
+var rangeDateLayouts = []string{"2006-01-02", "02/01/2006"}
+
 // DateService fornece operações relacionadas a datas.
 type DateService interface {
 	// GetPreviousMonthRange retorna o primeiro e último dia do mês anterior.
@@ -14,21 +31,30 @@ type DateService interface {
 	GetMonthRange(month, year int) (firstDay, lastDay time.Time)
 	// ParseMonthYear converte uma string MM/YYYY em mês e ano.
 	ParseMonthYear(date string) (month, year int, err error)
-	// FormatDateWorked formata a data trabalhada para exibição (MM/YYYY).
-	FormatDateWorked(date time.Time) string
+	// ParsePeriod converte uma expressão de período (MM/YYYY, YYYY-Www,
+	// Qn/YYYY ou "last N days") no intervalo correspondente.
+	ParsePeriod(expr string) (model.Period, error)
+	// ResolvePeriod determina o período do relatório a partir da expressão
+	// ou do intervalo from/to. Sem nenhum deles, usa o mês anterior.
+	ResolvePeriod(expr, from, to string) (model.Period, error)
 }
 
 // dateService implementa DateService.
-type dateService struct{}
+type dateService struct {
+	location *time.Location // Fuso horário em que o dia atual é determinado
+	now      func() time.Time
+}
 
-// NewDateService cria uma nova instância de DateService.
-func NewDateService() DateService {
-	return &dateService{}
+// NewDateService cria uma nova instância de DateService. O dia atual (mês
+// anterior, "last N days" e --from sem --to) é determinado no fuso horário
+// informado.
+func NewDateService(location *time.Location) DateService {
+	return &dateService{location: location, now: time.Now}
 }
 
 // GetPreviousMonthRange retorna o primeiro e último dia do mês anterior.
 func (s *dateService) GetPreviousMonthRange() (time.Time, time.Time) {
-	now := time.Now()
+	now := s.now().In(s.location)
 	year := now.Year()
 	month := now.Month() - 1
 
@@ -38,18 +64,13 @@ func (s *dateService) GetPreviousMonthRange() (time.Time, time.Time) {
 		year--
 	}
 
-	firstDay := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
-	lastDay := firstDay.AddDate(0, 1, -1)
-
-	return firstDay, lastDay
+	return s.GetMonthRange(int(month), year)
 }
 
 // GetMonthRange retorna o primeiro e último dia de um mês/ano específico.
 func (s *dateService) GetMonthRange(month, year int) (time.Time, time.Time) {
-	firstDay := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
-	lastDay := firstDay.AddDate(0, 1, -1)
-
-	return firstDay, lastDay
+	period := model.NewMonthPeriod(month, year)
+	return period.Start, period.End
 }
 
 // ParseMonthYear converte uma string MM/YYYY em mês e ano.
@@ -63,7 +84,123 @@ func (s *dateService) ParseMonthYear(date string) (int, int, error) {
 	return int(parsedDate.Month()), parsedDate.Year(), nil
 }
 
-// FormatDateWorked formata a data para o padrão MM/YYYY.
-func (s *dateService) FormatDateWorked(date time.Time) string {
-	return date.Format("01/2006")
+// ParsePeriod converte uma expressão de período no intervalo correspondente.
+func (s *dateService) ParsePeriod(expr string) (model.Period, error) {
+	expr = strings.TrimSpace(expr)
+	upper := strings.ToUpper(expr)
+
+	if match := isoWeekPattern.FindStringSubmatch(upper); match != nil {
+		year, _ := strconv.Atoi(match[1])
+		week, _ := strconv.Atoi(match[2])
+		return s.weekPeriod(week, year)
+	}
+
+	if match := quarterPattern.FindStringSubmatch(upper); match != nil {
+		quarter, _ := strconv.Atoi(match[1])
+		year, _ := strconv.Atoi(match[2])
+		return model.NewQuarterPeriod(quarter, year), nil
+	}
+
+	if match := lastDaysPattern.FindStringSubmatch(strings.ToLower(expr)); match != nil {
+		days, err := strconv.Atoi(match[1])
+		if err != nil || days < 1 {
+			return model.Period{}, fmt.Errorf(
+				"quantidade de dias inválida em '%s'", expr,
+			)
+		}
+		return s.lastDaysPeriod(days), nil
+	}
+
+	month, year, err := s.ParseMonthYear(expr)
+	if err != nil {
+		return model.Period{}, fmt.Errorf(
+			"período inválido '%s': use MM/YYYY (ex: 01/2025), YYYY-Www "+
+				"(ex: 2025-W14), Qn/YYYY (ex: Q1/2025) ou 'last N days'",
+			expr,
+		)
+	}
+	return model.NewMonthPeriod(month, year), nil
+}
+
+// ResolvePeriod determina o período do relatório.
+func (s *dateService) ResolvePeriod(
+	expr, from, to string,
+) (model.Period, error) {
+	if from == "" && to == "" {
+		if expr == "" {
+			first, _ := s.GetPreviousMonthRange()
+			return model.NewMonthPeriod(int(first.Month()), first.Year()), nil
+		}
+		return s.ParsePeriod(expr)
+	}
+
+	if expr != "" {
+		return model.Period{}, fmt.Errorf(
+			"informe o período com --date ou com --from/--to, não ambos",
+		)
+	}
+	if from == "" {
+		return model.Period{}, fmt.Errorf("--to exige que --from seja informado")
+	}
+
+	start, err := parseRangeDate(from)
+	if err != nil {
+		return model.Period{}, err
+	}
+
+	end := s.today()
+	if to != "" {
+		if end, err = parseRangeDate(to); err != nil {
+			return model.Period{}, err
+		}
+	}
+
+	if end.Before(start) {
+		return model.Period{}, fmt.Errorf(
+			"período inválido: o fim (%s) é anterior ao início (%s)",
+			end.Format("2006-01-02"), start.Format("2006-01-02"),
+		)
+	}
+	return model.NewRangePeriod(start, end), nil
+}
+
+// weekPeriod valida a semana ISO e retorna o período correspondente.
+func (s *dateService) weekPeriod(week, year int) (model.Period, error) {
+	period := model.NewWeekPeriod(week, year)
+
+	// A quinta-feira da semana define a qual ano ISO ela pertence
+	thursdayYear, _ := period.Start.AddDate(0, 0, 3).ISOWeek()
+	if week < 1 || thursdayYear != year {
+		return model.Period{}, fmt.Errorf(
+			"semana inválida: %d-W%02d não existe", year, week,
+		)
+	}
+	return period, nil
+}
+
+// lastDaysPeriod retorna os últimos N dias, incluindo o dia atual.
+func (s *dateService) lastDaysPeriod(days int) model.Period {
+	end := s.today()
+	period := model.NewRangePeriod(end.AddDate(0, 0, -(days-1)), end)
+	period.Name = fmt.Sprintf("últimos %d dias", days)
+	return period
+}
+
+// parseRangeDate converte uma data de --from/--to (YYYY-MM-DD ou DD/MM/YYYY).
+func parseRangeDate(value string) (time.Time, error) {
+	for _, layout := range rangeDateLayouts {
+		if date, err := time.Parse(layout, strings.TrimSpace(value)); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf(
+		"data inválida '%s': use YYYY-MM-DD (ex: 2025-01-15)", value,
+	)
+}
+
+// today retorna a data atual no fuso horário do serviço, representada à
+// meia-noite (UTC) como as demais datas de período.
+func (s *dateService) today() time.Time {
+	now := s.now().In(s.location)
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
 }
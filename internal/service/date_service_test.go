@@ -0,0 +1,132 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestDateService cria um DateService com o relógio fixo no instante
+// informado.
+func newTestDateService(t *testing.T, timeZone string, now time.Time) *dateService {
+	t.Helper()
+
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		t.Fatalf("fuso horário inválido: %v", err)
+	}
+	return &dateService{
+		location: location,
+		now:      func() time.Time { return now },
+	}
+}
+
+func TestPreviousMonthUsesServiceTimeZone(t *testing.T) {
+	// 01/02 às 01:30 em UTC ainda é 31/01 em São Paulo
+	now := time.Date(2025, time.February, 1, 1, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		timeZone string
+		expected string
+	}{
+		{"America/Sao_Paulo", "12/2024"},
+		{"UTC", "01/2025"},
+	}
+	for _, tt := range tests {
+		service := newTestDateService(t, tt.timeZone, now)
+
+		period, err := service.ResolvePeriod("", "", "")
+		if err != nil {
+			t.Fatalf("%s: erro inesperado: %v", tt.timeZone, err)
+		}
+		if period.Name != tt.expected {
+			t.Errorf(
+				"%s: mês anterior esperado %s, obtido %s",
+				tt.timeZone, tt.expected, period.Name,
+			)
+		}
+	}
+}
+
+func TestLastDaysEndsOnTodayInServiceTimeZone(t *testing.T) {
+	now := time.Date(2025, time.March, 1, 2, 0, 0, 0, time.UTC)
+	service := newTestDateService(t, "America/Sao_Paulo", now)
+
+	period, err := service.ParsePeriod("last 7 days")
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	// Em São Paulo ainda é 28/02: o período vai de 22/02 a 28/02
+	if got := period.Start.Format("2006-01-02"); got != "2025-02-22" {
+		t.Errorf("início esperado 2025-02-22, obtido %s", got)
+	}
+	if got := period.End.Format("2006-01-02"); got != "2025-02-28" {
+		t.Errorf("fim esperado 2025-02-28, obtido %s", got)
+	}
+}
+
+func TestResolvePeriod(t *testing.T) {
+	now := time.Date(2025, time.March, 15, 12, 0, 0, 0, time.UTC)
+	service := newTestDateService(t, "UTC", now)
+
+	tests := []struct {
+		name      string
+		expr      string
+		from, to  string
+		start     string // Início esperado (YYYY-MM-DD)
+		end       string // Fim esperado (YYYY-MM-DD)
+		fileLabel string // Rótulo esperado no nome do arquivo
+		err       string // Trecho esperado do erro (vazio quando válido)
+	}{
+		{name: "mês anterior", start: "2025-02-01", end: "2025-02-28", fileLabel: "02_2025"},
+		{name: "mês", expr: "12/2024", start: "2024-12-01", end: "2024-12-31", fileLabel: "12_2024"},
+		{name: "semana ISO", expr: "2025-W14", start: "2025-03-31", end: "2025-04-06", fileLabel: "2025-W14"},
+		{name: "semana sem hífen e minúscula", expr: "2025w2", start: "2025-01-06", end: "2025-01-12", fileLabel: "2025-W02"},
+		{name: "W01 no ano civil anterior", expr: "2025-W01", start: "2024-12-30", end: "2025-01-05", fileLabel: "2025-W01"},
+		{name: "W53 de ano longo", expr: "2020-W53", start: "2020-12-28", end: "2021-01-03", fileLabel: "2020-W53"},
+		{name: "W53 inexistente", expr: "2021-W53", err: "2021-W53 não existe"},
+		{name: "W00 inexistente", expr: "2025-W00", err: "2025-W00 não existe"},
+		{name: "Q1", expr: "Q1/2025", start: "2025-01-01", end: "2025-03-31", fileLabel: "Q1_2025"},
+		{name: "Q2", expr: "q2/2025", start: "2025-04-01", end: "2025-06-30", fileLabel: "Q2_2025"},
+		{name: "Q3", expr: "Q3/2025", start: "2025-07-01", end: "2025-09-30", fileLabel: "Q3_2025"},
+		{name: "Q4", expr: "Q4/2024", start: "2024-10-01", end: "2024-12-31", fileLabel: "Q4_2024"},
+		{name: "Q5 inexistente", expr: "Q5/2025", err: "período inválido"},
+		{name: "últimos 7 dias", expr: "last 7 days", start: "2025-03-09", end: "2025-03-15", fileLabel: "2025-03-09_2025-03-15"},
+		{name: "último dia", expr: "últimos 1 dia", start: "2025-03-15", end: "2025-03-15", fileLabel: "2025-03-15_2025-03-15"},
+		{name: "últimos 0 dias", expr: "last 0 days", err: "quantidade de dias inválida"},
+		{name: "últimos dias negativo", expr: "last -3 days", err: "período inválido"},
+		{name: "intervalo", from: "2025-01-10", to: "20/01/2025", start: "2025-01-10", end: "2025-01-20", fileLabel: "2025-01-10_2025-01-20"},
+		{name: "intervalo até hoje", from: "2025-03-01", start: "2025-03-01", end: "2025-03-15", fileLabel: "2025-03-01_2025-03-15"},
+		{name: "intervalo de um dia", from: "2025-01-10", to: "2025-01-10", start: "2025-01-10", end: "2025-01-10", fileLabel: "2025-01-10_2025-01-10"},
+		{name: "from depois de to", from: "2025-01-20", to: "2025-01-10", err: "o fim (2025-01-10) é anterior ao início (2025-01-20)"},
+		{name: "to sem from", to: "2025-01-10", err: "--to exige que --from seja informado"},
+		{name: "date com from", expr: "01/2025", from: "2025-01-10", err: "não ambos"},
+		{name: "data inválida", from: "2025-13-01", err: "data inválida '2025-13-01'"},
+	}
+	for _, tt := range tests {
+		period, err := service.ResolvePeriod(tt.expr, tt.from, tt.to)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%s: esperado erro contendo %q, obtido %v", tt.name, tt.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: erro inesperado: %v", tt.name, err)
+			continue
+		}
+
+		start := period.Start.Format(time.DateOnly)
+		end := period.End.Format(time.DateOnly)
+		if start != tt.start || end != tt.end {
+			t.Errorf(
+				"%s: esperado %s a %s, obtido %s a %s",
+				tt.name, tt.start, tt.end, start, end,
+			)
+		}
+		if got := period.FileLabel(); got != tt.fileLabel {
+			t.Errorf("%s: rótulo esperado %s, obtido %s", tt.name, tt.fileLabel, got)
+		}
+	}
+}
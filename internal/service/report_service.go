@@ -1,7 +1,9 @@
 package service
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -23,16 +25,19 @@ type reportService struct {
 	repo        repository.JiraRepository
 	dateService DateService
 	fileService FileService
-	generators  map[model.ReportFormat]view.ReportGenerator
+	generators  *view.Registry
+	progress    io.Writer // Mensagens de progresso e avisos
 }
 
-// NewReportService cria uma nova instância de ReportService.
+// NewReportService cria uma nova instância de ReportService. As mensagens
+// de progresso são escritas em progress, separadas da saída do relatório.
 func NewReportService(
 	cfg *config.Config,
 	repo repository.JiraRepository,
 	dateService DateService,
 	fileService FileService,
-	generators map[model.ReportFormat]view.ReportGenerator,
+	generators *view.Registry,
+	progress io.Writer,
 ) ReportService {
 	return &reportService{
 		config:      cfg,
@@ -40,166 +45,280 @@ func NewReportService(
 		dateService: dateService,
 		fileService: fileService,
 		generators:  generators,
+		progress:    progress,
 	}
 }
 
 // Generate gera um relatório com as opções especificadas.
 func (s *reportService) Generate(opts model.ReportOptions) error {
-	// Validar formato
-	if err := s.validateFormat(opts.Format); err != nil {
+	// Validar formatos
+	if err := s.validateFormats(opts.Formats); err != nil {
 		return err
 	}
+	if opts.Output != nil && len(opts.Formats) > 1 {
+		return fmt.Errorf("a saída padrão aceita apenas um formato")
+	}
 
-	// Buscar dados do Jira
-	reportData, err := s.fetchReportData(opts.Date, opts.IncludeQA)
+	// Determinar o período do relatório
+	period, sprint, err := s.resolvePeriod(opts)
 	if err != nil {
 		return err
 	}
 
-	// Determinar caminhos de arquivo
-	paths, err := s.resolvePaths(opts)
+	_, _, err = s.generateFor(opts, period, sprint, nil)
+	return err
+}
+
+// generateFor busca os dados uma única vez e gera o relatório de um usuário
+// em cada formato solicitado: o membro da equipe informado ou, quando nil, o
+// usuário configurado. A falha de um formato não interrompe os demais.
+// Retorna os dados do relatório e os caminhos dos arquivos gerados.
+func (s *reportService) generateFor(
+	opts model.ReportOptions, period model.Period, sprint *model.Sprint,
+	member *model.TeamMember,
+) (*model.ReportData, []string, error) {
+	// Buscar dados do Jira
+	reportData, err := s.fetchReportData(opts, period, sprint, member)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	// Gerar o relatório
-	if err := s.generateReport(reportData, paths, opts.Format); err != nil {
-		return err
+	// Escrever direto na saída informada (ex: --stdout), sem arquivo
+	if opts.Output != nil {
+		err := s.writeOutput(
+			reportData, opts.Formats[0], s.generateOptions(opts), opts.Output,
+		)
+		return reportData, nil, err
 	}
 
-	fmt.Printf("Relatório %s gerado com sucesso!\n", paths.finalPath)
-	return nil
+	// Todos os formatos compartilham o diretório e o nome base do arquivo
+	directory, err := s.outputDir(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	baseName := s.baseFileName(opts, period)
+
+	var files, failed []string
+	for _, format := range opts.Formats {
+		generator, _ := s.generators.Get(format)
+		path := s.reportPath(directory, baseName, generator.Extension())
+
+		err := s.writeReport(generator, reportData, s.generateOptions(opts), path)
+		if err != nil {
+			fmt.Fprintf(s.progress, "Falha ao gerar o relatório %s: %v\n", format, err)
+			failed = append(failed, fmt.Sprintf("%s: %v", format, err))
+			continue
+		}
+
+		fmt.Fprintf(s.progress, "Relatório %s gerado com sucesso!\n", path)
+		files = append(files, path)
+	}
+
+	if len(failed) > 0 {
+		return reportData, files, fmt.Errorf(
+			"falha ao gerar %d de %d formato(s): %s",
+			len(failed), len(opts.Formats), strings.Join(failed, "; "),
+		)
+	}
+	return reportData, files, nil
 }
 
-// reportPaths contém os caminhos necessários para geração.
-type reportPaths struct {
-	directory string
-	htmlPath  string
-	finalPath string
+// validateFormats valida se há ao menos um formato e se todos são
+// suportados.
+func (s *reportService) validateFormats(formats []model.ReportFormat) error {
+	if len(formats) == 0 {
+		return fmt.Errorf("nenhum formato de relatório informado")
+	}
+	for _, format := range formats {
+		if err := s.validateFormat(format); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// validateFormat valida se o formato é suportado.
+// validateFormat valida se há um gerador registrado para o formato.
 func (s *reportService) validateFormat(format model.ReportFormat) error {
-	if !format.IsValid() {
-		return fmt.Errorf("formato inválido: %s. Use 'html' ou 'docx'", format)
+	if _, exists := s.generators.Get(format); exists {
+		return nil
 	}
-	if _, exists := s.generators[format]; !exists {
-		return fmt.Errorf("gerador não disponível para formato: %s", format)
+
+	available := make([]string, 0, len(s.generators.Formats()))
+	for _, registered := range s.generators.Formats() {
+		available = append(available, string(registered))
 	}
-	return nil
+	return fmt.Errorf(
+		"formato inválido: %s. Use: %s", format, strings.Join(available, ", "),
+	)
+}
+
+// resolvePeriod determina o período do relatório. No modo sprint, o período
+// vem das datas da sprint resolvida no Jira Agile.
+func (s *reportService) resolvePeriod(
+	opts model.ReportOptions,
+) (model.Period, *model.Sprint, error) {
+	if opts.Sprint == "" {
+		period, err := s.dateService.ResolvePeriod(opts.Date, opts.From, opts.To)
+		return period, nil, err
+	}
+
+	if opts.Date != "" || opts.From != "" || opts.To != "" {
+		return model.Period{}, nil, fmt.Errorf(
+			"--sprint não pode ser combinado com --date, --from ou --to",
+		)
+	}
+	// A JQL avulsa substitui toda a consulta, inclusive a condição da sprint
+	if opts.JQL != "" {
+		return model.Period{}, nil, fmt.Errorf(
+			"--sprint não pode ser combinado com --jql: " +
+				"inclua a condição \"sprint = <id>\" na própria JQL",
+		)
+	}
+
+	sprint, err := s.repo.FetchSprint(opts.Board, opts.Sprint)
+	if err != nil {
+		return model.Period{}, nil, fmt.Errorf(
+			"erro ao resolver a sprint: %w", err,
+		)
+	}
+	fmt.Fprintf(s.progress,
+		"Sprint '%s' (%s a %s)\n", sprint.Name,
+		sprint.Start.Format("02/01/2006"), sprint.End.Format("02/01/2006"),
+	)
+	return sprint.Period(), sprint, nil
 }
 
-// fetchReportData busca e monta os dados do relatório.
+// fetchReportData busca e monta os dados do relatório. Com um membro da
+// equipe, busca as issues do accountId do membro e usa os seus dados.
 func (s *reportService) fetchReportData(
-	specifiedDate string, includeQA bool,
+	opts model.ReportOptions, period model.Period, sprint *model.Sprint,
+	member *model.TeamMember,
 ) (*model.ReportData, error) {
-	var firstDay, lastDay time.Time
-
-	// Usa a data especificada ou o mês anterior como padrão
-	if specifiedDate != "" {
-		month, year, err := s.dateService.ParseMonthYear(specifiedDate)
-		if err != nil {
-			return nil, err
-		}
-		firstDay, lastDay = s.dateService.GetMonthRange(month, year)
-	} else {
-		firstDay, lastDay = s.dateService.GetPreviousMonthRange()
+	profile, err := s.config.Profile(opts.Profile)
+	if err != nil {
+		return nil, err
 	}
 
-	issues, err := s.repo.FetchIssues(firstDay, lastDay, includeQA)
+	query := repository.IssueQuery{
+		StartDate: period.Start,
+		EndDate:   period.End,
+		IncludeQA: opts.IncludeQA,
+		Worklogs:  opts.Hours,
+		Profile:   profile,
+		JQL:       opts.JQL,
+	}
+	if sprint != nil {
+		query.SprintID = sprint.ID
+	}
+	if member != nil {
+		query.Assignee = member.AccountID
+	}
+	issues, err := s.repo.FetchIssues(query)
+	if errors.Is(err, repository.ErrNoIssues) && member != nil {
+		// Um membro sem issues no período recebe um relatório vazio
+		issues, err = model.NewIssueCollection(), nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("erro ao buscar dados do Jira: %w", err)
 	}
+	s.shapeDescriptions(issues)
 
 	user := model.NewUser(
 		s.config.CompanyName, s.config.CNPJ, s.config.Username,
 	)
-	dateWorked := s.dateService.FormatDateWorked(firstDay)
+	if member != nil {
+		user = &member.User
+	}
 
-	return model.NewReportData(*user, *issues, dateWorked), nil
+	reportData := model.NewReportData(*user, *issues, period)
+	reportData.Sprint = sprint
+	if opts.Hours {
+		reportData.Hours = model.NewHoursSummary(*issues, s.config.HourlyRate)
+	}
+	return reportData, nil
 }
 
-// resolvePaths determina os caminhos de arquivo para o relatório.
-func (s *reportService) resolvePaths(
-	opts model.ReportOptions,
-) (*reportPaths, error) {
-	// Determinar diretório
+// shapeDescriptions aplica o modo e o limite de tamanho configurados às
+// descrições exibidas no resumo das atividades.
+func (s *reportService) shapeDescriptions(issues *model.IssueCollection) {
+	mode := model.DescriptionMode(s.config.DescriptionMode)
+	for i := range issues.Items {
+		issues.Items[i].Description = issues.Items[i].Description.Shape(
+			mode, s.config.DescriptionMaxLength,
+		)
+	}
+}
+
+// outputDir determina o diretório dos relatórios, criando-o se necessário.
+func (s *reportService) outputDir(opts model.ReportOptions) (string, error) {
 	directory := opts.Path
 	if directory == "" {
 		directory = "reports"
 	}
 
 	if err := s.fileService.EnsureDir(directory); err != nil {
-		return nil, err
-	}
-
-	// Gerar nome do arquivo
-	fileName := s.generateFileName(opts)
-	finalPath := fmt.Sprintf("%s/%s", directory, fileName)
-
-	// Determinar caminho do HTML (pode ser temporário para DOCX)
-	htmlPath := finalPath
-	if opts.Format == model.FormatDOCX {
-		htmlPath = strings.TrimSuffix(finalPath, ".docx") + ".html"
+		return "", err
 	}
+	return directory, nil
+}
 
-	reportPath := &reportPaths{
-		directory: directory,
-		htmlPath:  htmlPath,
-		finalPath: finalPath,
-	}
-	return reportPath, nil
+// reportPath determina o caminho do arquivo do relatório com a extensão.
+func (s *reportService) reportPath(directory, baseName, extension string) string {
+	return fmt.Sprintf("%s/%s.%s", directory, baseName, extension)
 }
 
-// generateFileName gera o nome do arquivo baseado nas opções.
-func (s *reportService) generateFileName(opts model.ReportOptions) string {
-	now := time.Now()
-	day := now.Day()
-	ext := opts.Format.Extension()
+// baseFileName gera o nome do arquivo, sem extensão, baseado nas opções.
+func (s *reportService) baseFileName(
+	opts model.ReportOptions, period model.Period,
+) string {
+	day := time.Now().Day()
 
-	// Determina o mês/ano para o nome do arquivo
-	var monthAndYear string
-	if opts.Date != "" {
-		monthAndYear = strings.Replace(opts.Date, "/", "_", 1)
-	} else {
-		// Usa o mês anterior como padrão
-		previousMonth := now.AddDate(0, -1, 0)
-		monthAndYear = previousMonth.Format("01_2006")
-	}
+	// Identifica o período no nome do arquivo (ex: 01_2025, 2025-W14)
+	periodLabel := period.FileLabel()
 
 	if opts.Name == "" {
-		return fmt.Sprintf("report_%d_%s.%s", day, monthAndYear, ext)
+		return fmt.Sprintf("report_%d_%s", day, periodLabel)
 	}
-	return fmt.Sprintf("%s_%d_%s.%s", opts.Name, day, monthAndYear, ext)
+	return fmt.Sprintf("%s_%d_%s", opts.Name, day, periodLabel)
 }
 
-// generateReport gera o arquivo de relatório.
-func (s *reportService) generateReport(
-	data *model.ReportData, paths *reportPaths, format model.ReportFormat,
+// writeReport gera o relatório no arquivo, removendo o arquivo incompleto
+// em caso de falha.
+func (s *reportService) writeReport(
+	generator view.ReportGenerator, data *model.ReportData,
+	generateOpts view.GenerateOptions, path string,
 ) error {
-	// Sempre gerar HTML primeiro
-	htmlGenerator := s.generators[model.FormatHTML]
-	htmlFile, err := s.fileService.CreateFile(paths.htmlPath)
+	file, err := s.fileService.CreateFile(path)
 	if err != nil {
 		return err
 	}
 
-	if err := htmlGenerator.Generate(htmlFile, data); err != nil {
-		htmlFile.Close()
+	if err := generator.Generate(file, data, generateOpts); err != nil {
+		file.Close()
+		s.fileService.RemoveFile(path)
 		return err
 	}
-	htmlFile.Close()
+	return file.Close()
+}
 
-	// Se for DOCX, converter e remover HTML temporário
-	if format == model.FormatDOCX {
-		docxGenerator := s.generators[model.FormatDOCX]
-		if err := docxGenerator.Generate(
-			nil, data, paths.htmlPath, paths.finalPath,
-		); err != nil {
-			return err
-		}
-		s.fileService.RemoveFile(paths.htmlPath)
-	}
+// writeOutput gera o relatório no writer informado (ex: saída padrão).
+func (s *reportService) writeOutput(
+	data *model.ReportData, format model.ReportFormat,
+	generateOpts view.GenerateOptions, writer io.Writer,
+) error {
+	generator, _ := s.generators.Get(format)
+	return generator.Generate(writer, data, generateOpts)
+}
 
-	return nil
+// generateOptions monta as opções repassadas aos geradores, exibindo os
+// avisos de cada geração junto do progresso.
+func (s *reportService) generateOptions(
+	opts model.ReportOptions,
+) view.GenerateOptions {
+	return view.GenerateOptions{
+		TemplatePath: opts.TemplatePath,
+		Warn: func(warning view.Warning) {
+			fmt.Fprintf(s.progress, "Aviso (%s): %s\n", warning.Format, warning.Message)
+		},
+	}
 }
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+	"github.com/alan-gomes1/jira-reporter/internal/repository"
+	"github.com/alan-gomes1/jira-reporter/internal/view"
+)
+
+// fakeRepository implementa repository.JiraRepository em memória. As issues
+// e os erros da busca são indexados pelo accountId da consulta (vazio para
+// o usuário autenticado).
+type fakeRepository struct {
+	issues       map[string]*model.IssueCollection
+	fetchErrors  map[string]error
+	sprint       *model.Sprint
+	queries      []repository.IssueQuery
+	sprintLookup int // Quantidade de chamadas a FetchSprint
+}
+
+func (f *fakeRepository) FetchIssues(
+	query repository.IssueQuery,
+) (*model.IssueCollection, error) {
+	f.queries = append(f.queries, query)
+	if err := f.fetchErrors[query.Assignee]; err != nil {
+		return nil, err
+	}
+	if issues := f.issues[query.Assignee]; issues != nil {
+		return issues, nil
+	}
+	return nil, repository.ErrNoIssues
+}
+
+func (f *fakeRepository) FetchSprint(board, sprint string) (*model.Sprint, error) {
+	f.sprintLookup++
+	if f.sprint == nil {
+		return nil, fmt.Errorf("sprint '%s' não encontrada", sprint)
+	}
+	return f.sprint, nil
+}
+
+func (f *fakeRepository) FindAccountID(email string) (string, error) {
+	return "id-" + email, nil
+}
+
+func (f *fakeRepository) TimeZone() (*time.Location, error) {
+	return time.UTC, nil
+}
+
+func (f *fakeRepository) Authenticate() (string, error) {
+	return "Usuário", nil
+}
+
+func (f *fakeRepository) MissingPermissions(keys []string) ([]string, error) {
+	return nil, nil
+}
+
+func (f *fakeRepository) MissingFields(names []string) ([]string, error) {
+	return nil, nil
+}
+
+func TestResolvePeriodRejectsSprintWithJQL(t *testing.T) {
+	repo := &fakeRepository{sprint: &model.Sprint{ID: 7, Name: "Sprint 7"}}
+	service := &reportService{repo: repo}
+
+	opts := model.ReportOptions{Sprint: "7", JQL: "project = ABC"}
+	_, _, err := service.resolvePeriod(opts)
+	if err == nil || !strings.Contains(err.Error(), "--sprint não pode ser combinado com --jql") {
+		t.Errorf("esperado erro de --sprint com --jql, obtido %v", err)
+	}
+	if repo.sprintLookup != 0 {
+		t.Errorf("a sprint não deveria ser buscada, obtido %d busca(s)", repo.sprintLookup)
+	}
+}
+
+// failingGenerator é um gerador que escreve parte do relatório e falha.
+type failingGenerator struct {
+	view.ReportGenerator
+}
+
+func (g failingGenerator) Generate(
+	writer io.Writer, data *model.ReportData, opts view.GenerateOptions,
+) error {
+	io.WriteString(writer, "parcial")
+	return errors.New("falha simulada")
+}
+
+func TestGenerateForContinuesAfterFormatFailure(t *testing.T) {
+	issues := model.NewIssueCollection()
+	issues.Add(model.Issue{Key: "PROJ-1", Summary: "Tarefa"})
+	repo := &fakeRepository{issues: map[string]*model.IssueCollection{"": issues}}
+
+	generators := allGenerators()
+	generators.Register(failingGenerator{view.NewPDFGenerator()})
+	service := &reportService{
+		config: testReportConfig(), repo: repo, dateService: NewDateService(time.UTC),
+		fileService: NewFileService(), generators: generators, progress: io.Discard,
+	}
+
+	dir := t.TempDir()
+	template := filepath.Join(dir, "relatorio.html")
+	if err := os.WriteFile(template, []byte("Issues: {{count .Jira}}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := *model.NewReportOptions()
+	opts.Path = filepath.Join(dir, "saida")
+	opts.Name = "teste"
+	opts.Formats = []model.ReportFormat{model.FormatHTML, model.FormatPDF, model.FormatJSON}
+	opts.TemplatePath = template
+
+	_, files, err := service.generateFor(opts, model.NewMonthPeriod(1, 2025), nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "falha ao gerar 1 de 3 formato(s): pdf: falha simulada") {
+		t.Errorf("esperada a falha apenas do PDF, obtido %v", err)
+	}
+
+	if len(files) != 2 || filepath.Ext(files[0]) != ".html" || filepath.Ext(files[1]) != ".json" {
+		t.Fatalf("esperados os arquivos HTML e JSON, obtido %v", files)
+	}
+	html, err := os.ReadFile(files[0])
+	if err != nil || string(html) != "Issues: 1" {
+		t.Errorf("esperado o HTML do template informado, obtido %q (erro: %v)", html, err)
+	}
+
+	// O arquivo incompleto do formato que falhou é removido
+	pdf := strings.TrimSuffix(files[0], ".html") + ".pdf"
+	if _, err := os.Stat(pdf); !os.IsNotExist(err) {
+		t.Errorf("arquivo %s incompleto não removido: %v", pdf, err)
+	}
+}
+
+func TestGenerateKeepsProgressOutOfOutput(t *testing.T) {
+	issues := model.NewIssueCollection()
+	issues.Add(model.Issue{Key: "PROJ-1", Summary: "Tarefa"})
+	start := time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC)
+	repo := &fakeRepository{
+		issues: map[string]*model.IssueCollection{"": issues},
+		sprint: &model.Sprint{ID: 7, Name: "Sprint 7", Start: start, End: start.AddDate(0, 0, 13)},
+	}
+
+	var progress strings.Builder
+	service := NewReportService(
+		testReportConfig(), repo, NewDateService(time.UTC), NewFileService(),
+		allGenerators(), &progress,
+	)
+
+	var out strings.Builder
+	opts := *model.NewReportOptions()
+	opts.Sprint = "7"
+	opts.Formats = []model.ReportFormat{model.FormatJSON}
+	opts.Output = &out
+	if err := service.Generate(opts); err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	// A saída recebe apenas o relatório; o progresso vai para o seu destino
+	if !strings.HasPrefix(out.String(), "{") || strings.Contains(out.String(), "Sprint '") {
+		t.Errorf("esperado apenas o JSON na saída, obtido %q", out.String())
+	}
+	if !strings.Contains(progress.String(), "Sprint 'Sprint 7'") {
+		t.Errorf("esperada a sprint no progresso, obtido %q", progress.String())
+	}
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/alan-gomes1/jira-reporter/internal/config"
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+	"github.com/alan-gomes1/jira-reporter/internal/repository"
+	"github.com/alan-gomes1/jira-reporter/internal/view"
+)
+
+// TeamService orquestra a geração de relatórios para vários membros da equipe.
+type TeamService interface {
+	// Generate gera um relatório para cada membro da equipe e, se summary
+	// for true, um relatório consolidado em HTML.
+	Generate(
+		opts model.ReportOptions, members []model.TeamMember, summary bool,
+	) error
+}
+
+// teamService implementa TeamService reaproveitando a geração individual.
+type teamService struct {
+	reports          *reportService
+	summaryGenerator view.TeamSummaryGenerator
+}
+
+// NewTeamService cria uma nova instância de TeamService. As mensagens de
+// progresso de cada membro são escritas em progress.
+func NewTeamService(
+	cfg *config.Config,
+	repo repository.JiraRepository,
+	dateService DateService,
+	fileService FileService,
+	generators *view.Registry,
+	summaryGenerator view.TeamSummaryGenerator,
+	progress io.Writer,
+) TeamService {
+	return &teamService{
+		reports: &reportService{
+			config:      cfg,
+			repo:        repo,
+			dateService: dateService,
+			fileService: fileService,
+			generators:  generators,
+			progress:    progress,
+		},
+		summaryGenerator: summaryGenerator,
+	}
+}
+
+// Generate gera um relatório para cada membro da equipe. A falha de um
+// membro não interrompe os demais; as falhas são informadas ao final.
+func (s *teamService) Generate(
+	opts model.ReportOptions, members []model.TeamMember, summary bool,
+) error {
+	if err := s.reports.validateFormats(opts.Formats); err != nil {
+		return err
+	}
+	// A JQL avulsa substitui toda a consulta, inclusive o filtro por membro
+	if opts.JQL != "" {
+		return fmt.Errorf(
+			"--jql não pode ser usado no modo equipe: a consulta de cada " +
+				"membro é filtrada pelo seu accountId; use um perfil " +
+				"(--profile) para ajustar a consulta",
+		)
+	}
+
+	period, sprint, err := s.reports.resolvePeriod(opts)
+	if err != nil {
+		return err
+	}
+
+	teamSummary := &model.TeamSummary{
+		DateWorked: period.Label(),
+		Period:     period,
+		Sprint:     sprint,
+	}
+
+	var failed []string
+	for _, member := range members {
+		fmt.Fprintf(s.reports.progress, "Gerando relatório de %s...\n", member.User.Username)
+
+		report := s.generateMember(opts, period, sprint, member)
+		if report.Failed() {
+			fmt.Fprintf(s.reports.progress, "Falha no relatório de %s: %s\n", member.ID, report.Error)
+			failed = append(failed, member.ID)
+		}
+		teamSummary.Members = append(teamSummary.Members, report)
+	}
+
+	if summary {
+		if err := s.writeSummary(opts, teamSummary); err != nil {
+			return err
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf(
+			"falha ao gerar o relatório de %d membro(s): %s",
+			len(failed), strings.Join(failed, ", "),
+		)
+	}
+	return nil
+}
+
+// generateMember gera o relatório de um membro, resolvendo o accountId pelo
+// e-mail quando necessário.
+func (s *teamService) generateMember(
+	opts model.ReportOptions, period model.Period, sprint *model.Sprint,
+	member model.TeamMember,
+) model.MemberReport {
+	report := model.MemberReport{Member: member}
+
+	if member.AccountID == "" {
+		accountID, err := s.reports.repo.FindAccountID(member.Email)
+		if err != nil {
+			report.Error = err.Error()
+			return report
+		}
+		member.AccountID = accountID
+		report.Member = member
+	}
+
+	// Cada membro tem o próprio arquivo (ex: report_joao_5_01_2025.html)
+	opts.Name = memberReportName(opts.Name, member.ID)
+
+	data, files, err := s.reports.generateFor(opts, period, sprint, &member)
+	if err != nil {
+		report.Error = err.Error()
+	}
+	if data != nil {
+		report.Issues = data.Jira.Count()
+		report.Hours = data.Hours
+	}
+	report.Files = files
+	return report
+}
+
+// writeSummary gera o relatório consolidado da equipe em HTML, no mesmo
+// diretório dos relatórios individuais.
+func (s *teamService) writeSummary(
+	opts model.ReportOptions, summary *model.TeamSummary,
+) error {
+	opts.Name = memberReportName(opts.Name, "equipe")
+
+	directory, err := s.reports.outputDir(opts)
+	if err != nil {
+		return err
+	}
+	path := s.reports.reportPath(
+		directory, s.reports.baseFileName(opts, summary.Period), "html",
+	)
+
+	file, err := s.reports.fileService.CreateFile(path)
+	if err != nil {
+		return err
+	}
+	if err := s.summaryGenerator.Generate(file, summary); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(s.reports.progress, "Resumo da equipe %s gerado com sucesso!\n", path)
+	return nil
+}
+
+// memberReportName monta o nome do relatório com o sufixo informado.
+func memberReportName(name, suffix string) string {
+	if name == "" {
+		name = "report"
+	}
+	return name + "_" + suffix
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alan-gomes1/jira-reporter/internal/config"
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+	"github.com/alan-gomes1/jira-reporter/internal/view"
+)
+
+// captureSummaryGenerator guarda o resumo da equipe recebido.
+type captureSummaryGenerator struct {
+	summary *model.TeamSummary
+}
+
+func (g *captureSummaryGenerator) Generate(
+	writer io.Writer, summary *model.TeamSummary,
+) error {
+	g.summary = summary
+	return nil
+}
+
+// testReportConfig retorna a configuração mínima para gerar relatórios.
+func testReportConfig() *config.Config {
+	return &config.Config{
+		DefaultProfile: "default",
+		Profiles: map[string]model.QueryProfile{
+			"default": model.NewDefaultQueryProfile(),
+		},
+	}
+}
+
+// allGenerators registra os geradores de todos os formatos.
+func allGenerators() *view.Registry {
+	return view.NewRegistry(
+		view.NewHTMLGenerator(""),
+		view.NewDOCXGenerator(),
+		view.NewPDFGenerator(),
+		view.NewMarkdownGenerator(),
+		view.NewCSVGenerator(false),
+		view.NewXLSXGenerator(),
+		view.NewJSONGenerator(),
+	)
+}
+
+func TestTeamReportsEmptyMemberAndFailingMember(t *testing.T) {
+	issues := model.NewIssueCollection()
+	issues.Add(model.Issue{Key: "PROJ-1", Summary: "Tarefa"})
+	repo := &fakeRepository{
+		issues: map[string]*model.IssueCollection{"id-ana": issues},
+		fetchErrors: map[string]error{
+			"id-bruno": errors.New("erro HTTP 500"),
+		},
+	}
+	summaryGenerator := &captureSummaryGenerator{}
+	service := NewTeamService(
+		testReportConfig(), repo, NewDateService(time.UTC), NewFileService(),
+		allGenerators(), summaryGenerator, io.Discard,
+	)
+
+	opts := *model.NewReportOptions()
+	opts.Path = t.TempDir()
+	opts.Date = "01/2025"
+	opts.Formats = allGenerators().Formats()
+	members := []model.TeamMember{
+		{ID: "ana", AccountID: "id-ana"},
+		{ID: "bruno", AccountID: "id-bruno"},
+		{ID: "carla", Email: "carla"}, // Sem issues no período
+	}
+
+	err := service.Generate(opts, members, true)
+	if err == nil || !strings.Contains(err.Error(), "1 membro(s): bruno") {
+		t.Fatalf("esperada falha apenas de bruno, obtido %v", err)
+	}
+
+	reports := map[string]model.MemberReport{}
+	for _, report := range summaryGenerator.summary.Members {
+		reports[report.Member.ID] = report
+	}
+	if reports["ana"].Failed() || reports["ana"].Issues != 1 {
+		t.Errorf("ana: esperado relatório com 1 issue, obtido %+v", reports["ana"])
+	}
+	if !reports["bruno"].Failed() || !strings.Contains(reports["bruno"].Error, "erro HTTP 500") {
+		t.Errorf("bruno: esperada a falha da busca, obtido %+v", reports["bruno"])
+	}
+
+	empty := reports["carla"]
+	if empty.Failed() || empty.Issues != 0 {
+		t.Errorf("carla: esperado relatório vazio sem falha, obtido %+v", empty)
+	}
+	if len(empty.Files) != len(opts.Formats) {
+		t.Fatalf(
+			"carla: esperados %d arquivos, obtido %v", len(opts.Formats), empty.Files,
+		)
+	}
+	for _, path := range empty.Files {
+		if info, err := os.Stat(path); err != nil || info.Size() == 0 {
+			t.Errorf("carla: arquivo %s não gerado: %v", path, err)
+		}
+	}
+}
+
+func TestTeamRejectsJQL(t *testing.T) {
+	repo := &fakeRepository{}
+	summaryGenerator := &captureSummaryGenerator{}
+	service := NewTeamService(
+		testReportConfig(), repo, NewDateService(time.UTC), NewFileService(),
+		allGenerators(), summaryGenerator, io.Discard,
+	)
+
+	opts := *model.NewReportOptions()
+	opts.Path = t.TempDir()
+	opts.Date = "01/2025"
+	opts.JQL = "project = ABC"
+	members := []model.TeamMember{{ID: "ana", AccountID: "id-ana"}}
+
+	err := service.Generate(opts, members, true)
+	if err == nil || !strings.Contains(err.Error(), "--jql não pode ser usado no modo equipe") {
+		t.Errorf("esperado erro de --jql no modo equipe, obtido %v", err)
+	}
+	if len(repo.queries) != 0 || summaryGenerator.summary != nil {
+		t.Errorf("nenhuma busca ou resumo esperado, obtido %d busca(s)", len(repo.queries))
+	}
+}
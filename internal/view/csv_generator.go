@@ -0,0 +1,103 @@
+package view
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+)
+
+// Formato do CSV: separador ";" (padrão do Excel em português, em que a
+// vírgula é o separador decimal) e BOM que identifica o arquivo como UTF-8.
+const (
+	csvSeparator = ';'
+	utf8BOM      = "\uFEFF"
+)
+
+// csvGenerator implementa ReportGenerator para formato CSV, com os dados
+// do relatório no início do arquivo e uma linha por issue.
+type csvGenerator struct {
+	bom bool // Escreve o BOM UTF-8 para que o Excel reconheça os acentos
+}
+
+// NewCSVGenerator cria um novo gerador CSV. Com bom, o arquivo começa com o
+// BOM UTF-8, necessário para o Excel exibir corretamente os acentos.
+func NewCSVGenerator(bom bool) ReportGenerator {
+	return &csvGenerator{bom: bom}
+}
+
+// Generate escreve o relatório em formato CSV no writer.
+func (g *csvGenerator) Generate(
+	writer io.Writer, data *model.ReportData, opts GenerateOptions,
+) error {
+	if writer == nil {
+		return fmt.Errorf("writer não pode ser nil para geração CSV")
+	}
+
+	if g.bom {
+		if _, err := io.WriteString(writer, utf8BOM); err != nil {
+			return fmt.Errorf("erro ao gerar CSV: %w", err)
+		}
+	}
+
+	w := csv.NewWriter(writer)
+	w.Comma = csvSeparator
+
+	// Dados do relatório, separados da lista de atividades por uma linha vazia
+	for _, row := range metadataRows(data) {
+		w.Write([]string{csvText(row[0]), csvText(row[1])})
+	}
+	w.Write(nil)
+
+	header, rows := activityTable(data)
+	w.Write(header)
+	for _, row := range rows {
+		record := make([]string, len(row))
+		for i, value := range row {
+			record[i] = csvValue(value)
+		}
+		w.Write(record)
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("erro ao gerar CSV: %w", err)
+	}
+	return nil
+}
+
+// Format retorna o formato suportado.
+func (g *csvGenerator) Format() model.ReportFormat {
+	return model.FormatCSV
+}
+
+// Extension retorna a extensão dos arquivos gerados.
+func (g *csvGenerator) Extension() string {
+	return "csv"
+}
+
+// MIMEType retorna o tipo de conteúdo gerado.
+func (g *csvGenerator) MIMEType() string {
+	return "text/csv; charset=utf-8"
+}
+
+// csvValue formata o valor da célula, com vírgula decimal nos números.
+func csvValue(value sheetValue) string {
+	if value.IsNumber {
+		return formatHours(value.Number)
+	}
+	return csvText(value.Text)
+}
+
+// csvText protege o texto contra injeção de fórmulas: textos que começam
+// com um caractere que inicia fórmulas no Excel (ex: "=HYPERLINK(...)",
+// vindo do resumo da issue) recebem um apóstrofo no início, que faz a
+// planilha exibi-los como texto.
+func csvText(text string) string {
+	if text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
@@ -0,0 +1,112 @@
+package view
+
+import (
+	"bytes"
+	"encoding/csv"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// generateCSV gera o CSV dos dados de exemplo e separa as linhas de
+// metadados das linhas de atividades.
+func generateCSV(t *testing.T, bom bool, summary string) (string, [][]string, [][]string) {
+	t.Helper()
+
+	data := sampleReportData()
+	data.Jira.Items[0].Summary = summary
+	data.Jira.Items[0].Status = "-Bloqueada"
+	data.User.CompanyName = "@Empresa"
+
+	var out bytes.Buffer
+	if err := NewCSVGenerator(bom).Generate(&out, data, GenerateOptions{}); err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	content := out.String()
+
+	reader := csv.NewReader(strings.NewReader(strings.TrimPrefix(content, utf8BOM)))
+	reader.Comma = ';'
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("CSV inválido: %v", err)
+	}
+
+	// A linha vazia que separa os metadados não gera registro no leitor
+	for i, record := range records {
+		if record[0] == "DATA" {
+			return content, records[:i], records[i:]
+		}
+	}
+	t.Fatalf("cabeçalho das atividades não encontrado: %v", records)
+	return "", nil, nil
+}
+
+func TestCSVGeneratorLayout(t *testing.T) {
+	content, metadata, activities := generateCSV(t, false, "Exemplo; com \"aspas\"")
+	if strings.HasPrefix(content, utf8BOM) {
+		t.Error("BOM não deveria ser escrito sem a opção")
+	}
+	if !strings.Contains(content, "\n\nDATA;") {
+		t.Error("esperada uma linha vazia antes das atividades")
+	}
+
+	expectedMetadata := map[string]string{
+		"RAZÃO SOCIAL":        "'@Empresa",
+		"TOTAL DE ATIVIDADES": "1",
+		"TOTAL DO MÊS":        "1,00",
+		"VALOR TOTAL":         "R$ 100,00",
+	}
+	for _, record := range metadata {
+		if expected, exists := expectedMetadata[record[0]]; exists && record[1] != expected {
+			t.Errorf("%s: esperado %q, obtido %q", record[0], expected, record[1])
+		}
+		delete(expectedMetadata, record[0])
+	}
+	if len(expectedMetadata) > 0 {
+		t.Errorf("metadados ausentes: %v", expectedMetadata)
+	}
+
+	expected := [][]string{
+		{"DATA", "ID DA TAREFA", "ATIVIDADE", "STATUS", "URL", "HORAS"},
+		{
+			"06/01/2025", "PROJ-1", "Exemplo; com \"aspas\"", "'-Bloqueada",
+			"https://example.atlassian.net/browse/PROJ-1", "1,00",
+		},
+	}
+	if len(activities) != len(expected) {
+		t.Fatalf("esperadas %d linhas de atividades, obtido %v", len(expected), activities)
+	}
+	for i := range expected {
+		if !slices.Equal(activities[i], expected[i]) {
+			t.Errorf("linha %d: esperado %q, obtido %q", i, expected[i], activities[i])
+		}
+	}
+}
+
+func TestCSVGeneratorWritesBOM(t *testing.T) {
+	content, _, _ := generateCSV(t, true, "Exemplo")
+	if !strings.HasPrefix(content, utf8BOM+"RAZÃO SOCIAL;") {
+		t.Errorf("esperado o BOM UTF-8 no início, obtido %q", content[:20])
+	}
+}
+
+func TestCSVTextGuardsFormulas(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{`=HYPERLINK("http://example.com","x")`, `'=HYPERLINK("http://example.com","x")`},
+		{"+5", "'+5"},
+		{"-1", "'-1"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\t=1", "'\t=1"},
+		{"Resumo = normal", "Resumo = normal"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := csvText(tt.text); got != tt.expected {
+			t.Errorf("csvText(%q): esperado %q, obtido %q", tt.text, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package view
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"net/url"
+	"strings"
+
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+)
+
+// descriptionHTML renderiza a descrição estruturada como HTML seguro para o
+// template, com parágrafos, títulos, listas, links e blocos de código.
+func descriptionHTML(desc model.Description) template.HTML {
+	var b strings.Builder
+	writeBlocksHTML(&b, desc.Blocks)
+	return template.HTML(b.String())
+}
+
+// writeBlocksHTML escreve os blocos da descrição como HTML.
+func writeBlocksHTML(b *strings.Builder, blocks []model.Block) {
+	for _, block := range blocks {
+		switch block.Type {
+		case model.BlockParagraph:
+			b.WriteString("<p>" + inlinesHTML(block.Inlines) + "</p>")
+		case model.BlockHeading:
+			level := min(max(block.Level, 1), 6)
+			fmt.Fprintf(b, "<h%d>%s</h%d>", level, inlinesHTML(block.Inlines), level)
+		case model.BlockBulletList, model.BlockOrderedList:
+			tag := "ul"
+			if block.Type == model.BlockOrderedList {
+				tag = "ol"
+			}
+			if block.Type == model.BlockOrderedList && block.ListStart() != 1 {
+				fmt.Fprintf(b, `<ol start="%d">`, block.ListStart())
+			} else {
+				b.WriteString("<" + tag + ">")
+			}
+			for _, item := range block.Items {
+				b.WriteString("<li>")
+				writeBlocksHTML(b, item.Blocks)
+				b.WriteString("</li>")
+			}
+			b.WriteString("</" + tag + ">")
+		case model.BlockCode:
+			b.WriteString("<pre><code>" + html.EscapeString(block.Text) + "</code></pre>")
+		case model.BlockQuote:
+			b.WriteString("<blockquote>")
+			writeBlocksHTML(b, block.Children)
+			b.WriteString("</blockquote>")
+		case model.BlockRule:
+			b.WriteString("<hr>")
+		}
+	}
+}
+
+// inlinesHTML renderiza trechos de texto com suas marcações como HTML.
+func inlinesHTML(inlines []model.Inline) string {
+	var b strings.Builder
+	for _, inline := range inlines {
+		if inline.Break {
+			b.WriteString("<br>")
+			continue
+		}
+
+		text := html.EscapeString(inline.Text)
+		if inline.Code {
+			text = "<code>" + text + "</code>"
+		}
+		if inline.Bold {
+			text = "<b>" + text + "</b>"
+		}
+		if inline.Italic {
+			text = "<i>" + text + "</i>"
+		}
+		if inline.Underline {
+			text = "<u>" + text + "</u>"
+		}
+		if inline.Strike {
+			text = "<s>" + text + "</s>"
+		}
+		if link := safeLink(inline.Link); link != "" {
+			text = fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(link), text)
+		}
+		b.WriteString(text)
+	}
+	return b.String()
+}
+
+// safeLinkSchemes são os esquemas aceitos nos links da descrição.
+var safeLinkSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+
+// safeLink retorna a URL do link quando o esquema é http, https ou mailto.
+// Outros esquemas (ex: javascript:) e URLs inválidas retornam vazio, e o
+// trecho é exibido como texto comum.
+func safeLink(link string) string {
+	link = strings.TrimSpace(link)
+	parsed, err := url.Parse(link)
+	if err != nil || !safeLinkSchemes[strings.ToLower(parsed.Scheme)] {
+		return ""
+	}
+	return link
+}
+
+// descriptionMarkdown renderiza a descrição estruturada como Markdown.
+func descriptionMarkdown(desc model.Description) string {
+	return strings.Join(blocksMarkdown(desc.Blocks), "\n\n")
+}
+
+// blocksMarkdown converte os blocos da descrição em trechos Markdown.
+func blocksMarkdown(blocks []model.Block) []string {
+	parts := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		var text string
+		switch block.Type {
+		case model.BlockParagraph:
+			text = inlinesMarkdown(block.Inlines)
+		case model.BlockHeading:
+			level := min(max(block.Level, 1), 6)
+			text = strings.Repeat("#", level) + " " + inlinesMarkdown(block.Inlines)
+		case model.BlockBulletList, model.BlockOrderedList:
+			text = listMarkdown(block)
+		case model.BlockCode:
+			fence := markdownFence(block.Text, 3)
+			text = fence + block.Language + "\n" + block.Text + "\n" + fence
+		case model.BlockQuote:
+			quoted := strings.Join(blocksMarkdown(block.Children), "\n\n")
+			text = "> " + strings.ReplaceAll(quoted, "\n", "\n> ")
+		case model.BlockRule:
+			text = "---"
+		}
+		if strings.TrimSpace(text) != "" {
+			parts = append(parts, text)
+		}
+	}
+	return parts
+}
+
+// listMarkdown converte uma lista em Markdown, recuando o conteúdo aninhado.
+func listMarkdown(block model.Block) string {
+	lines := make([]string, 0, len(block.Items))
+	for i, item := range block.Items {
+		marker := "- "
+		if block.Type == model.BlockOrderedList {
+			marker = fmt.Sprintf("%d. ", block.ListStart()+i)
+		}
+		content := strings.Join(blocksMarkdown(item.Blocks), "\n")
+		indent := strings.Repeat(" ", len(marker))
+		lines = append(lines, marker+strings.ReplaceAll(content, "\n", "\n"+indent))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// markdownEscaper escapa caracteres com significado especial no Markdown.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`,
+	"[", `\[`, "]", `\]`, "|", `\|`, "<", "&lt;",
+)
+
+// inlinesMarkdown renderiza trechos de texto com suas marcações em Markdown.
+func inlinesMarkdown(inlines []model.Inline) string {
+	var b strings.Builder
+	for _, inline := range inlines {
+		if inline.Break {
+			b.WriteString("  \n")
+			continue
+		}
+		if inline.Text == "" {
+			continue
+		}
+
+		text := markdownEscaper.Replace(inline.Text)
+		if inline.Code {
+			text = markdownCode(inline.Text)
+		}
+		if inline.Bold {
+			text = "**" + text + "**"
+		}
+		if inline.Italic {
+			text = "_" + text + "_"
+		}
+		if inline.Strike {
+			text = "~~" + text + "~~"
+		}
+		if link := safeLink(inline.Link); link != "" {
+			text = fmt.Sprintf("[%s](%s)", text, markdownURL(link))
+		}
+		b.WriteString(text)
+	}
+	return b.String()
+}
+
+// markdownURLEscaper codifica os caracteres que encerrariam o destino de um
+// link Markdown antes do fim da URL.
+var markdownURLEscaper = strings.NewReplacer(
+	" ", "%20", "(", "%28", ")", "%29", "<", "%3C", ">", "%3E",
+	"\n", "%0A", "\r", "%0D", "\t", "%09",
+)
+
+// markdownURL prepara a URL para o destino de um link Markdown.
+func markdownURL(link string) string {
+	return markdownURLEscaper.Replace(link)
+}
+
+// markdownCode delimita o código com uma crase a mais que a maior sequência
+// de crases do texto, separando-o com espaços quando começa ou termina com
+// crase, para que as crases do próprio código não encerrem o trecho.
+func markdownCode(text string) string {
+	fence := markdownFence(text, 1)
+	if strings.HasPrefix(text, "`") || strings.HasSuffix(text, "`") {
+		text = " " + text + " "
+	}
+	return fence + text + fence
+}
+
+// markdownFence retorna uma sequência de crases com pelo menos minimum
+// crases e maior que qualquer sequência presente no texto.
+func markdownFence(text string, minimum int) string {
+	longest, run := 0, 0
+	for _, r := range text {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return strings.Repeat("`", max(minimum, longest+1))
+}
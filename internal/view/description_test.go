@@ -0,0 +1,104 @@
+package view
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+)
+
+func TestDescriptionHTMLOnlyLinksSafeSchemes(t *testing.T) {
+	tests := []struct {
+		link     string
+		expected string // Âncora esperada (vazio quando o link é descartado)
+	}{
+		{"https://example.com/a?b=1&c=2", `<a href="https://example.com/a?b=1&amp;c=2">`},
+		{"http://example.com", `<a href="http://example.com">`},
+		{"mailto:qa@example.com", `<a href="mailto:qa@example.com">`},
+		{"javascript:alert(1)", ""},
+		{" JavaScript:alert(1)", ""},
+		{"java\tscript:alert(1)", ""},
+		{"data:text/html,<script>alert(1)</script>", ""},
+		{"vbscript:msgbox", ""},
+		{"/relativo", ""},
+	}
+	for _, tt := range tests {
+		desc := model.Description{Blocks: []model.Block{{
+			Type:    model.BlockParagraph,
+			Inlines: []model.Inline{{Text: "clique", Link: tt.link}},
+		}}}
+
+		got := string(descriptionHTML(desc))
+		if tt.expected == "" {
+			if strings.Contains(got, "<a") {
+				t.Errorf("%q: link inseguro mantido: %s", tt.link, got)
+			}
+			if !strings.Contains(got, "clique") {
+				t.Errorf("%q: texto do link descartado: %s", tt.link, got)
+			}
+			continue
+		}
+		if !strings.Contains(got, tt.expected) {
+			t.Errorf("%q: esperado %s em %s", tt.link, tt.expected, got)
+		}
+	}
+}
+
+func TestDescriptionMarkdownInlines(t *testing.T) {
+	tests := []struct {
+		name     string
+		inline   model.Inline
+		expected string
+	}{
+		{"texto escapado", model.Inline{Text: "a*b_c [d] <e>"}, `a\*b\_c \[d\] &lt;e>`},
+		{"negrito e itálico", model.Inline{Text: "x", Bold: true, Italic: true}, "_**x**_"},
+		{"código simples", model.Inline{Text: "go test ./...", Code: true}, "`go test ./...`"},
+		{"código com crase", model.Inline{Text: "a`b", Code: true}, "``a`b``"},
+		{"código com crases duplas", model.Inline{Text: "a``b`", Code: true}, "``` a``b` ```"},
+		{"código começando com crase", model.Inline{Text: "`x", Code: true}, "`` `x ``"},
+		{
+			"link com parênteses",
+			model.Inline{Text: "wiki", Link: "https://pt.wikipedia.org/wiki/Go_(linguagem)"},
+			"[wiki](https://pt.wikipedia.org/wiki/Go_%28linguagem%29)",
+		},
+		{
+			"link com espaço",
+			model.Inline{Text: "doc", Link: "https://example.com/a b.pdf"},
+			"[doc](https://example.com/a%20b.pdf)",
+		},
+		{
+			"link com sinais de menor e maior",
+			model.Inline{Text: "busca", Link: "https://example.com/?q=<x>"},
+			"[busca](https://example.com/?q=%3Cx%3E)",
+		},
+		{"link inseguro", model.Inline{Text: "x", Link: "javascript:alert(1)"}, "x"},
+	}
+	for _, tt := range tests {
+		got := inlinesMarkdown([]model.Inline{tt.inline})
+		if got != tt.expected {
+			t.Errorf("%s: esperado %q, obtido %q", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestDescriptionMarkdownBlocks(t *testing.T) {
+	desc := model.Description{Blocks: []model.Block{
+		{Type: model.BlockHeading, Level: 2, Inlines: []model.Inline{{Text: "Título"}}},
+		{Type: model.BlockCode, Language: "md", Text: "```go\nfmt.Println()\n```"},
+		{Type: model.BlockBulletList, Items: []model.ListItem{
+			{Blocks: []model.Block{{Type: model.BlockParagraph, Inlines: []model.Inline{{Text: "um"}}}}},
+			{Blocks: []model.Block{{Type: model.BlockParagraph, Inlines: []model.Inline{{Text: "dois"}}}}},
+		}},
+		{Type: model.BlockQuote, Children: []model.Block{
+			{Type: model.BlockParagraph, Inlines: []model.Inline{{Text: "citação"}, {Break: true}, {Text: "fim"}}},
+		}},
+	}}
+
+	expected := "## Título\n\n" +
+		"````md\n```go\nfmt.Println()\n```\n````\n\n" +
+		"- um\n- dois\n\n" +
+		"> citação  \n> fim"
+	if got := descriptionMarkdown(desc); got != expected {
+		t.Errorf("esperado:\n%s\nobtido:\n%s", expected, got)
+	}
+}
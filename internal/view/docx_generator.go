@@ -1,55 +1,89 @@
 package view
 
 import (
+	"archive/zip"
 	"bytes"
+	"encoding/xml"
 	"fmt"
 	"io"
-	"os"
-	"os/exec"
-	"path/filepath"
 	"strings"
 
 	"github.com/alan-gomes1/jira-reporter/internal/model"
 )
 
-// docxGenerator implementa ReportGenerator para formato DOCX.
-type docxGenerator struct {
-	fileService fileRenamer
-}
+// Partes fixas do pacote WordprocessingML.
+const (
+	docxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+<Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>
+<Override PartName="/docProps/core.xml" ContentType="application/vnd.openxmlformats-package.core-properties+xml"/>
+</Types>`
 
-// fileRenamer interface mínima para operações de arquivo.
-type fileRenamer interface {
-	RenameFile(oldPath, newPath string) error
-}
+	docxPackageRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties" Target="docProps/core.xml"/>
+</Relationships>`
 
-// NewDOCXGenerator cria um novo gerador DOCX.
-func NewDOCXGenerator(fileService fileRenamer) ReportGenerator {
-	return &docxGenerator{
-		fileService: fileService,
-	}
+	docxCoreProps = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/">
+<dc:title>Relatório de Prestação de Serviços</dc:title>
+</cp:coreProperties>`
+
+	docxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:docDefaults>
+<w:rPrDefault><w:rPr><w:rFonts w:ascii="Arial" w:hAnsi="Arial" w:eastAsia="Arial" w:cs="Arial"/><w:sz w:val="22"/><w:szCs w:val="22"/><w:lang w:val="pt-BR"/></w:rPr></w:rPrDefault>
+<w:pPrDefault><w:pPr><w:spacing w:after="0" w:line="240" w:lineRule="auto"/></w:pPr></w:pPrDefault>
+</w:docDefaults>
+<w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/><w:qFormat/></w:style>
+<w:style w:type="character" w:styleId="Hyperlink"><w:name w:val="Hyperlink"/><w:rPr><w:color w:val="0563C1"/><w:u w:val="single"/></w:rPr></w:style>
+<w:style w:type="table" w:default="1" w:styleId="TableNormal"><w:name w:val="Normal Table"/><w:tblPr><w:tblCellMar><w:left w:w="108" w:type="dxa"/><w:right w:w="108" w:type="dxa"/></w:tblCellMar></w:tblPr></w:style>
+</w:styles>`
+
+	docxNamespaces = `xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"`
+
+	docxHyperlinkRelType = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink"
+	docxStylesRelType    = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles"
+
+	// docxHeaderShading é a cor de fundo das células de cabeçalho (#CCCCCC).
+	docxHeaderShading = "CCCCCC"
+	// docxTableWidth é a largura total das tabelas em cinquentésimos de
+	// porcento (5000 = 100%).
+	docxTableWidth = 5000
+	// docxTextWidth é a largura útil da página A4 com as margens usadas,
+	// em twips, usada para calcular a grade das tabelas.
+	docxTextWidth = 9638
+)
+
+// docxGenerator implementa ReportGenerator escrevendo o pacote DOCX
+// (WordprocessingML) diretamente, sem depender de ferramentas externas.
+type docxGenerator struct{}
+
+// NewDOCXGenerator cria um novo gerador DOCX nativo.
+func NewDOCXGenerator() ReportGenerator {
+	return &docxGenerator{}
 }
 
-// Generate converte HTML para DOCX usando LibreOffice.
-// args[0]: caminho do arquivo HTML de entrada
-// args[1]: caminho do arquivo DOCX de saída
+// Generate escreve o relatório em formato DOCX no writer.
 func (g *docxGenerator) Generate(
-	writer io.Writer, data *model.ReportData, args ...string,
+	writer io.Writer, data *model.ReportData, opts GenerateOptions,
 ) error {
-	if len(args) < 2 {
-		return fmt.Errorf(
-			"argumentos insuficientes: necessário htmlPath e docxPath",
-		)
+	if writer == nil {
+		return fmt.Errorf("writer não pode ser nil para geração DOCX")
 	}
 
-	htmlPath := args[0]
-	docxPath := args[1]
+	doc := newDocxDocument()
+	doc.writeReport(data)
 
-	loPath, err := g.findLibreOffice()
-	if err != nil {
-		return err
+	if err := doc.writePackage(writer); err != nil {
+		return fmt.Errorf("erro ao gerar DOCX: %w", err)
 	}
-
-	return g.convert(loPath, htmlPath, docxPath)
+	return nil
 }
 
 // Format retorna o formato suportado.
@@ -57,58 +91,568 @@ func (g *docxGenerator) Format() model.ReportFormat {
 	return model.FormatDOCX
 }
 
-// findLibreOffice localiza o executável do LibreOffice.
-func (g *docxGenerator) findLibreOffice() (string, error) {
-	loPath, err := exec.LookPath("libreoffice")
-	if err == nil {
-		return loPath, nil
+// Extension retorna a extensão dos arquivos gerados.
+func (g *docxGenerator) Extension() string {
+	return "docx"
+}
+
+// MIMEType retorna o tipo de conteúdo gerado.
+func (g *docxGenerator) MIMEType() string {
+	return "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+}
+
+// docxRun descreve a formatação de um trecho de texto.
+type docxRun struct {
+	Text      string
+	Bold      bool
+	Italic    bool
+	Underline bool
+	Strike    bool
+	Code      bool   // Usa fonte monoespaçada
+	Size      int    // Tamanho em meios pontos (0 usa o padrão)
+	Link      string // URL externa (transforma o trecho em hyperlink)
+	Break     bool   // Quebra de linha forçada
+	Hyperlink bool
+}
+
+// docxParagraphStyle descreve o alinhamento e o recuo de um parágrafo.
+type docxParagraphStyle struct {
+	Align  string // Vazio (esquerda) ou "center"
+	Indent int    // Recuo à esquerda em twips
+}
+
+// docxCell descreve uma célula de tabela.
+type docxCell struct {
+	Paragraphs []string // Parágrafos já serializados
+	Width      int      // Largura em cinquentésimos de porcento (0 = automática)
+	Shaded     bool
+}
+
+// docxDocument acumula o corpo do documento e as relações de hyperlinks.
+type docxDocument struct {
+	body  strings.Builder
+	links []string
+}
+
+// newDocxDocument cria um documento vazio.
+func newDocxDocument() *docxDocument {
+	return &docxDocument{}
+}
+
+// writeReport escreve o conteúdo do relatório reproduzindo o template HTML.
+func (d *docxDocument) writeReport(data *model.ReportData) {
+	d.writeHeaderTable(data)
+
+	d.emptyParagraphs(2)
+	d.body.WriteString(d.paragraph("center", docxRun{
+		Text: "RELATÓRIO DE PRESTAÇÃO DE SERVIÇOS", Bold: true, Size: 24,
+	}))
+	d.emptyParagraphs(1)
+
+	d.writeActivityTable(data)
+
+	if data.Hours != nil {
+		d.emptyParagraphs(2)
+		d.writeHoursTable(data.Hours, data.Period)
 	}
 
-	// Tenta encontrar o soffice (nome alternativo)
-	loPath, err = exec.LookPath("soffice")
-	if err == nil {
-		return loPath, nil
+	d.emptyParagraphs(2)
+	d.writeSummaryTable(data)
+
+	d.emptyParagraphs(8)
+	d.body.WriteString(d.paragraph("center", docxRun{
+		Text: "___________________________", Bold: true,
+	}))
+	d.body.WriteString(d.paragraph("center", docxRun{
+		Text: "RESPONSÁVEL LEGAL(ASSINAR COM GOV.BR)", Bold: true,
+	}))
+}
+
+// writeHeaderTable escreve a tabela com os dados da empresa.
+func (d *docxDocument) writeHeaderTable(data *model.ReportData) {
+	type headerRow struct {
+		label  string
+		value  string
+		italic bool
+	}
+	rows := []headerRow{
+		{"RAZÃO SOCIAL", data.User.CompanyName, true},
+		{"CNPJ", data.User.CNPJ, true},
+		{"RESPONSÁVEL LEGAL", data.User.Username, true},
+		{"PROJETO", "GOVONE", false},
+		{periodTitle(data.Period), data.DateWorked, false},
+	}
+	if data.Sprint != nil && data.Sprint.Goal != "" {
+		rows = append(rows, headerRow{"OBJETIVO DA SPRINT", data.Sprint.Goal, false})
 	}
 
-	return "", fmt.Errorf(
-		"LibreOffice não está instalado. Exemplo de instalação: " +
-			"sudo apt-get install libreoffice-writer",
-	)
+	d.startTable(140, 1400, 3600)
+	for _, row := range rows {
+		d.tableRow(
+			docxCell{
+				Paragraphs: []string{
+					d.paragraph("", docxRun{Text: row.label, Bold: true}),
+				},
+				Width:  1400,
+				Shaded: true,
+			},
+			docxCell{
+				Paragraphs: []string{
+					d.paragraph("", docxRun{Text: row.value, Italic: row.italic}),
+				},
+				Width: 3600,
+			},
+		)
+	}
+	d.endTable()
 }
 
-// convert executa a conversão de HTML para DOCX.
-func (g *docxGenerator) convert(loPath, htmlPath, docxPath string) error {
-	outputDir := filepath.Dir(docxPath)
+// writeActivityTable escreve a tabela de atividades com links para as issues.
+// Quando há horas registradas, inclui a coluna de horas por tarefa.
+func (d *docxDocument) writeActivityTable(data *model.ReportData) {
+	widths := []int{500, 750, 3750}
+	if data.Hours != nil {
+		widths = []int{500, 750, 3250, 500}
+	}
+
+	header := []docxCell{
+		d.headerCell("DATA", widths[0]),
+		d.headerCell("ID DA TAREFA", widths[1]),
+		d.headerCell("ATIVIDADE", widths[2]),
+	}
+	if data.Hours != nil {
+		header = append(header, d.headerCell("HORAS", widths[3]))
+	}
+
+	d.startTable(160, widths...)
+	d.tableRow(header...)
+	for _, issue := range data.Jira.Items {
+		cells := []docxCell{
+			{
+				Paragraphs: []string{d.paragraph("", docxRun{Text: formatDay(issue.Date())})},
+				Width:      widths[0],
+			},
+			{
+				Paragraphs: []string{d.linkParagraph(issue.URL, issue.Key)},
+				Width:      widths[1],
+			},
+			{
+				Paragraphs: []string{d.paragraph("", docxRun{Text: issue.Summary})},
+				Width:      widths[2],
+			},
+		}
+		if data.Hours != nil {
+			cells = append(cells, d.textCell(formatHours(issue.Hours()), widths[3], false))
+		}
+		d.tableRow(cells...)
+	}
+	d.endTable()
+}
+
+// writeHoursTable escreve as horas trabalhadas por dia, o total do período
+// e, quando há valor da hora configurado, o valor a faturar.
+func (d *docxDocument) writeHoursTable(
+	hours *model.HoursSummary, period model.Period,
+) {
+	widths := []int{1250, 3750}
 
-	cmd := exec.Command(
-		loPath,
-		"--headless",
-		"--convert-to",
-		"docx:MS Word 2007 XML",
-		"--outdir",
-		outputDir,
-		htmlPath,
+	d.startTable(160, widths...)
+	d.tableRow(
+		d.headerCell("DIA", widths[0]),
+		d.headerCell("HORAS TRABALHADAS", widths[1]),
 	)
+	for _, day := range hours.Days {
+		d.tableRow(
+			d.textCell(formatDay(day.Date), widths[0], false),
+			d.textCell(formatHours(day.Hours()), widths[1], false),
+		)
+	}
+	d.tableRow(
+		d.headerCell(periodTotalLabel(period), widths[0]),
+		d.headerCell(formatHours(hours.TotalHours()), widths[1]),
+	)
+	if hours.HasRate() {
+		d.tableRow(
+			d.textCell("VALOR DA HORA", widths[0], true),
+			d.textCell(formatCurrency(hours.HourlyRate), widths[1], false),
+		)
+		d.tableRow(
+			d.headerCell("VALOR TOTAL", widths[0]),
+			d.headerCell(formatCurrency(hours.Amount()), widths[1]),
+		)
+	}
+	d.endTable()
+}
 
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
+// writeSummaryTable escreve o resumo com a descrição de cada issue.
+func (d *docxDocument) writeSummaryTable(data *model.ReportData) {
+	paragraphs := make([]string, 0, data.Jira.Count())
+	for _, issue := range data.Jira.Items {
+		paragraphs = append(paragraphs, d.descriptionParagraphs(
+			[]docxRun{{Text: issue.Key + ":", Bold: true}, {Text: " "}},
+			issue.Description,
+		)...)
+	}
 
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf(
-			"erro ao executar LibreOffice: %w - %s", err, stderr.String(),
+	d.startTable(160, docxTableWidth)
+	d.tableRow(d.headerCell("RESUMO DAS ATIVIDADES", docxTableWidth))
+	d.tableRow(docxCell{Paragraphs: paragraphs, Width: docxTableWidth})
+	d.endTable()
+}
+
+// headerCell cria uma célula de cabeçalho sombreada com texto em negrito.
+func (d *docxDocument) headerCell(text string, width int) docxCell {
+	return docxCell{
+		Paragraphs: []string{d.paragraph("", docxRun{Text: text, Bold: true})},
+		Width:      width,
+		Shaded:     true,
+	}
+}
+
+// textCell cria uma célula simples com texto, opcionalmente em negrito.
+func (d *docxDocument) textCell(text string, width int, bold bool) docxCell {
+	return docxCell{
+		Paragraphs: []string{d.paragraph("", docxRun{Text: text, Bold: bold})},
+		Width:      width,
+	}
+}
+
+// emptyParagraphs escreve n parágrafos vazios (equivalente aos <br>).
+func (d *docxDocument) emptyParagraphs(n int) {
+	for i := 0; i < n; i++ {
+		d.body.WriteString("<w:p/>")
+	}
+}
+
+// paragraph serializa um parágrafo com os trechos informados.
+// align pode ser vazio (esquerda) ou "center".
+func (d *docxDocument) paragraph(align string, runs ...docxRun) string {
+	return d.styledParagraph(docxParagraphStyle{Align: align}, runs...)
+}
+
+// styledParagraph serializa um parágrafo com alinhamento e recuo.
+func (d *docxDocument) styledParagraph(
+	style docxParagraphStyle, runs ...docxRun,
+) string {
+	var props strings.Builder
+	if style.Indent > 0 {
+		fmt.Fprintf(&props, `<w:ind w:left="%d"/>`, style.Indent)
+	}
+	if style.Align != "" {
+		fmt.Fprintf(&props, `<w:jc w:val="%s"/>`, style.Align)
+	}
+
+	var b strings.Builder
+	b.WriteString("<w:p>")
+	if props.Len() > 0 {
+		b.WriteString("<w:pPr>" + props.String() + "</w:pPr>")
+	}
+	for _, run := range runs {
+		if run.Link != "" {
+			b.WriteString(d.hyperlink(run.Link, run))
+			continue
+		}
+		b.WriteString(d.run(run))
+	}
+	b.WriteString("</w:p>")
+	return b.String()
+}
+
+// linkParagraph serializa um parágrafo contendo apenas um hyperlink.
+func (d *docxDocument) linkParagraph(url, text string) string {
+	return d.paragraph("", docxRun{Text: text, Link: url})
+}
+
+// descriptionParagraphs converte a descrição estruturada em parágrafos,
+// adicionando prefix ao início do primeiro parágrafo.
+func (d *docxDocument) descriptionParagraphs(
+	prefix []docxRun, desc model.Description,
+) []string {
+	var paragraphs []string
+	d.appendBlocks(&paragraphs, &prefix, desc.Blocks, 0)
+
+	// Descrição vazia: mantém apenas o prefixo (ex: chave da issue)
+	if len(prefix) > 0 {
+		paragraphs = append(paragraphs, d.paragraph("", prefix...))
+	}
+	return paragraphs
+}
+
+// appendBlocks serializa blocos da descrição com o recuo informado.
+// O prefixo é consumido pelo primeiro parágrafo gerado.
+func (d *docxDocument) appendBlocks(
+	paragraphs *[]string, prefix *[]docxRun, blocks []model.Block, indent int,
+) {
+	emit := func(style docxParagraphStyle, runs []docxRun) {
+		style.Indent += indent
+		runs = append(*prefix, runs...)
+		*prefix = nil
+		*paragraphs = append(*paragraphs, d.styledParagraph(style, runs...))
+	}
+
+	for _, block := range blocks {
+		switch block.Type {
+		case model.BlockParagraph:
+			emit(docxParagraphStyle{}, inlineRuns(block.Inlines))
+		case model.BlockHeading:
+			runs := inlineRuns(block.Inlines)
+			for i := range runs {
+				runs[i].Bold = true
+			}
+			emit(docxParagraphStyle{}, runs)
+		case model.BlockCode:
+			runs := []docxRun{{Text: block.Text, Code: true}}
+			emit(docxParagraphStyle{Indent: 284}, runs)
+		case model.BlockQuote:
+			if len(*prefix) > 0 {
+				emit(docxParagraphStyle{}, nil)
+			}
+			d.appendBlocks(paragraphs, prefix, block.Children, indent+567)
+		case model.BlockRule:
+			emit(docxParagraphStyle{}, []docxRun{{Text: "———"}})
+		case model.BlockBulletList, model.BlockOrderedList:
+			if len(*prefix) > 0 {
+				emit(docxParagraphStyle{}, nil)
+			}
+			for i, item := range block.Items {
+				marker := "• "
+				if block.Type == model.BlockOrderedList {
+					marker = fmt.Sprintf("%d. ", block.ListStart()+i)
+				}
+				*prefix = []docxRun{{Text: marker}}
+				d.appendBlocks(paragraphs, prefix, listItemBlocks(item), indent+567)
+				*prefix = nil
+			}
+		}
+	}
+}
+
+// listItemBlocks retorna os blocos do item, garantindo ao menos um
+// parágrafo para exibir o marcador de itens vazios.
+func listItemBlocks(item model.ListItem) []model.Block {
+	if len(item.Blocks) == 0 {
+		return []model.Block{{Type: model.BlockParagraph}}
+	}
+	return item.Blocks
+}
+
+// inlineRuns converte trechos da descrição em trechos do DOCX.
+func inlineRuns(inlines []model.Inline) []docxRun {
+	runs := make([]docxRun, 0, len(inlines))
+	for _, inline := range inlines {
+		runs = append(runs, docxRun{
+			Text:      inline.Text,
+			Bold:      inline.Bold,
+			Italic:    inline.Italic,
+			Underline: inline.Underline,
+			Strike:    inline.Strike,
+			Code:      inline.Code,
+			Link:      safeLink(inline.Link),
+			Break:     inline.Break,
+		})
+	}
+	return runs
+}
+
+// hyperlink serializa um hyperlink externo, registrando sua relação.
+func (d *docxDocument) hyperlink(url string, run docxRun) string {
+	run.Hyperlink = true
+	return fmt.Sprintf(
+		`<w:hyperlink r:id="%s" w:history="1">%s</w:hyperlink>`,
+		d.addLink(url), d.run(run),
+	)
+}
+
+// run serializa um trecho de texto com sua formatação.
+func (d *docxDocument) run(run docxRun) string {
+	var props strings.Builder
+	if run.Hyperlink {
+		props.WriteString(`<w:rStyle w:val="Hyperlink"/>`)
+	}
+	if run.Code {
+		props.WriteString(
+			`<w:rFonts w:ascii="Courier New" w:hAnsi="Courier New" w:cs="Courier New"/>`,
 		)
 	}
+	if run.Bold {
+		props.WriteString("<w:b/>")
+	}
+	if run.Italic {
+		props.WriteString("<w:i/>")
+	}
+	if run.Strike {
+		props.WriteString("<w:strike/>")
+	}
+	if run.Size > 0 {
+		fmt.Fprintf(&props, `<w:sz w:val="%d"/><w:szCs w:val="%d"/>`, run.Size, run.Size)
+	}
+	if run.Underline {
+		props.WriteString(`<w:u w:val="single"/>`)
+	}
 
-	// LibreOffice gera o arquivo com o mesmo nome do HTML mas com extensão .docx
-	generatedDocx := strings.TrimSuffix(htmlPath, ".html") + ".docx"
-	if generatedDocx != docxPath {
-		if err := g.fileService.RenameFile(generatedDocx, docxPath); err != nil {
-			// Fallback para renomeação direta
-			if err := os.Rename(generatedDocx, docxPath); err != nil {
-				return fmt.Errorf("erro ao renomear arquivo DOCX: %w", err)
+	var b strings.Builder
+	b.WriteString("<w:r>")
+	if props.Len() > 0 {
+		b.WriteString("<w:rPr>" + props.String() + "</w:rPr>")
+	}
+	if run.Break {
+		b.WriteString("<w:br/></w:r>")
+		return b.String()
+	}
+
+	// Quebras de linha e tabulações no texto viram <w:br/> e <w:tab/>
+	for i, line := range strings.Split(run.Text, "\n") {
+		if i > 0 {
+			b.WriteString("<w:br/>")
+		}
+		for j, part := range strings.Split(line, "\t") {
+			if j > 0 {
+				b.WriteString("<w:tab/>")
+			}
+			if part != "" {
+				b.WriteString(`<w:t xml:space="preserve">` + escapeXML(part) + "</w:t>")
 			}
 		}
 	}
+	b.WriteString("</w:r>")
+	return b.String()
+}
 
-	return nil
+// startTable abre uma tabela com bordas simples, margem interna em twips e
+// colunas com as larguras informadas em cinquentésimos de porcento.
+func (d *docxDocument) startTable(cellMargin int, columns ...int) {
+	fmt.Fprintf(&d.body,
+		`<w:tbl><w:tblPr><w:tblW w:w="%d" w:type="pct"/>`+
+			`<w:tblBorders>`+
+			`<w:top w:val="single" w:sz="4" w:space="0" w:color="000000"/>`+
+			`<w:left w:val="single" w:sz="4" w:space="0" w:color="000000"/>`+
+			`<w:bottom w:val="single" w:sz="4" w:space="0" w:color="000000"/>`+
+			`<w:right w:val="single" w:sz="4" w:space="0" w:color="000000"/>`+
+			`<w:insideH w:val="single" w:sz="4" w:space="0" w:color="000000"/>`+
+			`<w:insideV w:val="single" w:sz="4" w:space="0" w:color="000000"/>`+
+			`</w:tblBorders>`+
+			`<w:tblCellMar><w:top w:w="%[2]d" w:type="dxa"/>`+
+			`<w:left w:w="%[2]d" w:type="dxa"/>`+
+			`<w:bottom w:w="%[2]d" w:type="dxa"/>`+
+			`<w:right w:w="%[2]d" w:type="dxa"/></w:tblCellMar>`+
+			`</w:tblPr>`,
+		docxTableWidth, cellMargin,
+	)
+
+	d.body.WriteString("<w:tblGrid>")
+	for _, width := range columns {
+		fmt.Fprintf(&d.body,
+			`<w:gridCol w:w="%d"/>`, width*docxTextWidth/docxTableWidth,
+		)
+	}
+	d.body.WriteString("</w:tblGrid>")
+}
+
+// tableRow escreve uma linha da tabela.
+func (d *docxDocument) tableRow(cells ...docxCell) {
+	d.body.WriteString("<w:tr>")
+	for _, cell := range cells {
+		d.body.WriteString("<w:tc><w:tcPr>")
+		if cell.Width > 0 {
+			fmt.Fprintf(&d.body, `<w:tcW w:w="%d" w:type="pct"/>`, cell.Width)
+		}
+		if cell.Shaded {
+			fmt.Fprintf(&d.body,
+				`<w:shd w:val="clear" w:color="auto" w:fill="%s"/>`,
+				docxHeaderShading,
+			)
+		}
+		d.body.WriteString("</w:tcPr>")
+
+		// Toda célula precisa de ao menos um parágrafo
+		if len(cell.Paragraphs) == 0 {
+			d.body.WriteString("<w:p/>")
+		}
+		for _, paragraph := range cell.Paragraphs {
+			d.body.WriteString(paragraph)
+		}
+		d.body.WriteString("</w:tc>")
+	}
+	d.body.WriteString("</w:tr>")
+}
+
+// endTable fecha a tabela atual.
+func (d *docxDocument) endTable() {
+	d.body.WriteString("</w:tbl>")
+}
+
+// addLink registra a URL como relação externa e retorna seu id.
+// O id rId1 é reservado para os estilos.
+func (d *docxDocument) addLink(url string) string {
+	for i, existing := range d.links {
+		if existing == url {
+			return fmt.Sprintf("rId%d", i+2)
+		}
+	}
+	d.links = append(d.links, url)
+	return fmt.Sprintf("rId%d", len(d.links)+1)
+}
+
+// documentXML retorna o conteúdo de word/document.xml.
+func (d *docxDocument) documentXML() string {
+	return `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		"<w:document " + docxNamespaces + "><w:body>" +
+		d.body.String() +
+		`<w:sectPr><w:pgSz w:w="11906" w:h="16838"/>` +
+		`<w:pgMar w:top="1134" w:right="1134" w:bottom="1134" w:left="1134" ` +
+		`w:header="709" w:footer="709" w:gutter="0"/></w:sectPr>` +
+		"</w:body></w:document>"
+}
+
+// documentRelsXML retorna as relações do documento (estilos e hyperlinks).
+func (d *docxDocument) documentRelsXML() string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`)
+	b.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	fmt.Fprintf(&b,
+		`<Relationship Id="rId1" Type="%s" Target="styles.xml"/>`,
+		docxStylesRelType,
+	)
+	for i, url := range d.links {
+		fmt.Fprintf(&b,
+			`<Relationship Id="rId%d" Type="%s" Target="%s" TargetMode="External"/>`,
+			i+2, docxHyperlinkRelType, escapeXML(url),
+		)
+	}
+	b.WriteString("</Relationships>")
+	return b.String()
+}
+
+// writePackage escreve o pacote ZIP do DOCX no writer.
+func (d *docxDocument) writePackage(writer io.Writer) error {
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", docxContentTypes},
+		{"_rels/.rels", docxPackageRels},
+		{"docProps/core.xml", docxCoreProps},
+		{"word/document.xml", d.documentXML()},
+		{"word/styles.xml", docxStyles},
+		{"word/_rels/document.xml.rels", d.documentRelsXML()},
+	}
+
+	archive := zip.NewWriter(writer)
+	for _, part := range parts {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(file, part.content); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// escapeXML escapa caracteres especiais para uso em conteúdo XML.
+func escapeXML(text string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(text))
+	return b.String()
 }
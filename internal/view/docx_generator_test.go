@@ -0,0 +1,130 @@
+package view
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+)
+
+// relationshipsNamespace é o namespace dos atributos r:id do documento.
+const relationshipsNamespace = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+
+// readZipFile lê um arquivo do pacote ZIP gerado.
+func readZipFile(t *testing.T, archive *zip.Reader, name string) []byte {
+	t.Helper()
+
+	file, err := archive.Open(name)
+	if err != nil {
+		t.Fatalf("arquivo %s não encontrado no pacote: %v", name, err)
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("erro ao ler %s: %v", name, err)
+	}
+	return content
+}
+
+// openZip abre o pacote ZIP gerado em memória.
+func openZip(t *testing.T, content []byte) *zip.Reader {
+	t.Helper()
+
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		t.Fatalf("pacote ZIP inválido: %v", err)
+	}
+	return archive
+}
+
+func TestDOCXHyperlinksHaveRelationships(t *testing.T) {
+	data := sampleReportData()
+	data.Jira.Items[0].Description = model.Description{Blocks: []model.Block{
+		{Type: model.BlockParagraph, Inlines: []model.Inline{
+			{Text: "doc", Link: "https://example.com/doc?a=1&b=2"},
+			{Text: " e "},
+			{Text: "a mesma doc", Link: "https://example.com/doc?a=1&b=2"},
+		}},
+		{Type: model.BlockBulletList, Items: []model.ListItem{{Blocks: []model.Block{
+			{Type: model.BlockParagraph, Inlines: []model.Inline{
+				{Text: "outra", Link: "https://example.com/outra"},
+			}},
+		}}}},
+	}}
+	second := data.Jira.Items[0]
+	second.Key = "PROJ-2"
+	second.URL = "https://example.atlassian.net/browse/PROJ-2"
+	data.Jira.Add(second)
+
+	var out bytes.Buffer
+	if err := NewDOCXGenerator().Generate(&out, data, GenerateOptions{}); err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	archive := openZip(t, out.Bytes())
+
+	var rels struct {
+		Relationships []struct {
+			ID         string `xml:"Id,attr"`
+			Target     string `xml:"Target,attr"`
+			TargetMode string `xml:"TargetMode,attr"`
+		} `xml:"Relationship"`
+	}
+	relsXML := readZipFile(t, archive, "word/_rels/document.xml.rels")
+	if err := xml.Unmarshal(relsXML, &rels); err != nil {
+		t.Fatalf("document.xml.rels inválido: %v", err)
+	}
+	targets := map[string]string{}
+	for _, rel := range rels.Relationships {
+		if _, ok := targets[rel.ID]; ok {
+			t.Errorf("id de relação repetido: %s", rel.ID)
+		}
+		targets[rel.ID] = rel.Target
+		if rel.Target != "styles.xml" && rel.TargetMode != "External" {
+			t.Errorf("relação %s sem TargetMode External", rel.ID)
+		}
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(readZipFile(t, archive, "word/document.xml")))
+	linked := map[string]bool{}
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("document.xml inválido: %v", err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "hyperlink" {
+			continue
+		}
+
+		var id string
+		for _, attr := range start.Attr {
+			if attr.Name.Space == relationshipsNamespace && attr.Name.Local == "id" {
+				id = attr.Value
+			}
+		}
+		target, ok := targets[id]
+		if !ok {
+			t.Errorf("hyperlink com r:id %q sem relação correspondente", id)
+			continue
+		}
+		linked[target] = true
+	}
+
+	for _, url := range []string{
+		"https://example.com/doc?a=1&b=2",
+		"https://example.com/outra",
+		"https://example.atlassian.net/browse/PROJ-1",
+		"https://example.atlassian.net/browse/PROJ-2",
+	} {
+		if !linked[url] {
+			t.Errorf("nenhum hyperlink aponta para %s", url)
+		}
+	}
+}
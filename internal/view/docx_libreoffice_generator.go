@@ -0,0 +1,148 @@
+package view
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+)
+
+// libreOfficeGenerator implementa ReportGenerator para formato DOCX
+// convertindo o relatório HTML com o LibreOffice. É mantido como alternativa
+// ao gerador nativo para quem precisa da formatação produzida pelo LibreOffice.
+type libreOfficeGenerator struct {
+	html ReportGenerator // Gera o HTML convertido pelo LibreOffice
+}
+
+// NewLibreOfficeDOCXGenerator cria um gerador DOCX baseado no LibreOffice,
+// que converte o relatório gerado pelo gerador HTML informado.
+func NewLibreOfficeDOCXGenerator(html ReportGenerator) ReportGenerator {
+	return &libreOfficeGenerator{
+		html: html,
+	}
+}
+
+// Generate gera o HTML em um diretório temporário, converte-o para DOCX com
+// o LibreOffice e escreve o resultado no writer.
+func (g *libreOfficeGenerator) Generate(
+	writer io.Writer, data *model.ReportData, opts GenerateOptions,
+) error {
+	if writer == nil {
+		return fmt.Errorf("writer não pode ser nil para geração DOCX")
+	}
+
+	loPath, err := FindLibreOffice()
+	if err != nil {
+		return err
+	}
+
+	workDir, err := os.MkdirTemp("", "jira-reporter-docx-")
+	if err != nil {
+		return fmt.Errorf("erro ao criar diretório temporário: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	htmlPath := filepath.Join(workDir, "report."+g.html.Extension())
+	if err := g.writeHTML(htmlPath, data, opts); err != nil {
+		return err
+	}
+
+	docxPath, err := g.convert(loPath, htmlPath)
+	if err != nil {
+		return err
+	}
+
+	docx, err := os.Open(docxPath)
+	if err != nil {
+		return fmt.Errorf("erro ao abrir o DOCX convertido: %w", err)
+	}
+	defer docx.Close()
+
+	if _, err := io.Copy(writer, docx); err != nil {
+		return fmt.Errorf("erro ao escrever DOCX: %w", err)
+	}
+	return nil
+}
+
+// writeHTML gera o relatório HTML que será convertido.
+func (g *libreOfficeGenerator) writeHTML(
+	path string, data *model.ReportData, opts GenerateOptions,
+) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("erro ao criar HTML temporário: %w", err)
+	}
+	if err := g.html.Generate(file, data, opts); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Format retorna o formato suportado.
+func (g *libreOfficeGenerator) Format() model.ReportFormat {
+	return model.FormatDOCX
+}
+
+// Extension retorna a extensão dos arquivos gerados.
+func (g *libreOfficeGenerator) Extension() string {
+	return "docx"
+}
+
+// MIMEType retorna o tipo de conteúdo gerado.
+func (g *libreOfficeGenerator) MIMEType() string {
+	return "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+}
+
+// FindLibreOffice localiza o executável do LibreOffice usado pelo motor
+// DOCX libreoffice.
+func FindLibreOffice() (string, error) {
+	loPath, err := exec.LookPath("libreoffice")
+	if err == nil {
+		return loPath, nil
+	}
+
+	// Tenta encontrar o soffice (nome alternativo)
+	loPath, err = exec.LookPath("soffice")
+	if err == nil {
+		return loPath, nil
+	}
+
+	return "", fmt.Errorf(
+		"LibreOffice não está instalado. Exemplo de instalação: " +
+			"sudo apt-get install libreoffice-writer",
+	)
+}
+
+// convert executa a conversão de HTML para DOCX no diretório do HTML e
+// retorna o caminho do DOCX gerado.
+func (g *libreOfficeGenerator) convert(loPath, htmlPath string) (string, error) {
+	outputDir := filepath.Dir(htmlPath)
+
+	cmd := exec.Command(
+		loPath,
+		"--headless",
+		"--convert-to",
+		"docx:MS Word 2007 XML",
+		"--outdir",
+		outputDir,
+		htmlPath,
+	)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf(
+			"erro ao executar LibreOffice: %w - %s", err, stderr.String(),
+		)
+	}
+
+	// LibreOffice gera o arquivo com o mesmo nome do HTML mas com extensão .docx
+	return strings.TrimSuffix(htmlPath, filepath.Ext(htmlPath)) + ".docx", nil
+}
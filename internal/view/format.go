@@ -0,0 +1,89 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+)
+
+// dayFormat é o formato de exibição dos dias nas tabelas do relatório.
+const dayFormat = "02/01"
+
+// formatHours formata horas com duas casas decimais e vírgula (ex: 7,50).
+func formatHours(hours float64) string {
+	return strings.Replace(fmt.Sprintf("%.2f", hours), ".", ",", 1)
+}
+
+// formatCurrency formata um valor em reais (ex: R$ 1.234,56).
+func formatCurrency(value float64) string {
+	cents := fmt.Sprintf("%.2f", value)
+	integer, decimal, _ := strings.Cut(cents, ".")
+
+	negative := strings.HasPrefix(integer, "-")
+	integer = strings.TrimPrefix(integer, "-")
+
+	// Agrupa os milhares com ponto
+	var groups []string
+	for len(integer) > 3 {
+		groups = append([]string{integer[len(integer)-3:]}, groups...)
+		integer = integer[:len(integer)-3]
+	}
+	groups = append([]string{integer}, groups...)
+
+	formatted := "R$ " + strings.Join(groups, ".") + "," + decimal
+	if negative {
+		formatted = "-" + formatted
+	}
+	return formatted
+}
+
+// formatDay formata um dia para as tabelas do relatório (ex: 05/01). Datas
+// desconhecidas (instante zero) resultam em texto vazio.
+func formatDay(day time.Time) string {
+	if day.IsZero() {
+		return ""
+	}
+	return day.Format(dayFormat)
+}
+
+// periodTitle retorna o rótulo do período no cabeçalho do relatório.
+func periodTitle(period model.Period) string {
+	switch period.Kind {
+	case model.PeriodMonth:
+		return "MÊS/ANO DE COMPETÊNCIA"
+	case model.PeriodSprint:
+		return "SPRINT"
+	default:
+		return "PERÍODO"
+	}
+}
+
+// periodTotalLabel retorna o rótulo do total de horas do período.
+func periodTotalLabel(period model.Period) string {
+	switch period.Kind {
+	case model.PeriodMonth:
+		return "TOTAL DO MÊS"
+	case model.PeriodSprint:
+		return "TOTAL DA SPRINT"
+	default:
+		return "TOTAL DO PERÍODO"
+	}
+}
+
+// headerRows retorna os rótulos e valores do cabeçalho do relatório (dados
+// da empresa, período e objetivo da sprint).
+func headerRows(data *model.ReportData) [][2]string {
+	rows := [][2]string{
+		{"RAZÃO SOCIAL", data.User.CompanyName},
+		{"CNPJ", data.User.CNPJ},
+		{"RESPONSÁVEL LEGAL", data.User.Username},
+		{"PROJETO", "GOVONE"},
+		{periodTitle(data.Period), data.DateWorked},
+	}
+	if data.Sprint != nil && data.Sprint.Goal != "" {
+		rows = append(rows, [2]string{"OBJETIVO DA SPRINT", data.Sprint.Goal})
+	}
+	return rows
+}
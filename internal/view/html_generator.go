@@ -2,50 +2,85 @@ package view
 
 import (
 	"fmt"
-	"html/template"
 	"io"
+	"sync"
 
 	"github.com/alan-gomes1/jira-reporter/internal/model"
 )
 
-// htmlGenerator implementa ReportGenerator para formato HTML.
+// htmlGenerator implementa ReportGenerator para formato HTML. Os templates
+// são carregados uma única vez por caminho e reutilizados nas gerações
+// seguintes (ex: um relatório por membro da equipe).
 type htmlGenerator struct {
 	templatePath string
+
+	mu    sync.Mutex
+	cache map[string]*templateLibrary
 }
 
-// NewHTMLGenerator cria um novo gerador HTML.
+// NewHTMLGenerator cria um novo gerador HTML. Com templatePath vazio, usa o
+// template padrão embutido no binário.
 func NewHTMLGenerator(templatePath string) ReportGenerator {
 	return &htmlGenerator{
 		templatePath: templatePath,
+		cache:        map[string]*templateLibrary{},
 	}
 }
 
 // Generate gera o relatório em formato HTML.
 func (g *htmlGenerator) Generate(
-	writer io.Writer, data *model.ReportData, args ...string,
+	writer io.Writer, data *model.ReportData, opts GenerateOptions,
 ) error {
 	if writer == nil {
 		return fmt.Errorf("writer não pode ser nil para geração HTML")
 	}
 
 	templatePath := g.templatePath
-	if len(args) > 0 && args[0] != "" {
-		templatePath = args[0]
+	if opts.TemplatePath != "" {
+		templatePath = opts.TemplatePath
 	}
 
-	tmpl, err := template.ParseFiles(templatePath)
+	library, err := g.library(templatePath)
 	if err != nil {
 		return fmt.Errorf("erro ao parsear template: %w", err)
 	}
 
-	if err := tmpl.Execute(writer, data); err != nil {
+	if err := library.execute(writer, data); err != nil {
 		return fmt.Errorf("erro ao executar template: %w", err)
 	}
 
 	return nil
 }
 
+// library retorna a biblioteca de templates do caminho, carregando-a apenas
+// na primeira chamada.
+func (g *htmlGenerator) library(templatePath string) (*templateLibrary, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if library, exists := g.cache[templatePath]; exists {
+		return library, nil
+	}
+
+	library, err := loadTemplateLibrary(templatePath)
+	if err != nil {
+		return nil, err
+	}
+	g.cache[templatePath] = library
+	return library, nil
+}
+
 // Format retorna o formato suportado.
 func (g *htmlGenerator) Format() model.ReportFormat {
 	return model.FormatHTML
 }
+
+// Extension retorna a extensão dos arquivos gerados.
+func (g *htmlGenerator) Extension() string {
+	return "html"
+}
+
+// MIMEType retorna o tipo de conteúdo gerado.
+func (g *htmlGenerator) MIMEType() string {
+	return "text/html; charset=utf-8"
+}
@@ -0,0 +1,55 @@
+package view
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestHTMLGeneratorReusesLoadedTemplates(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTemplate(t, dir, "relatorio.html", "primeira {{.User.CompanyName}}")
+	generator := NewHTMLGenerator(path)
+
+	generate := func(opts GenerateOptions) string {
+		t.Helper()
+		var out bytes.Buffer
+		if err := generator.Generate(&out, sampleReportData(), opts); err != nil {
+			t.Fatalf("erro inesperado: %v", err)
+		}
+		return out.String()
+	}
+
+	if got := generate(GenerateOptions{}); got != "primeira Empresa" {
+		t.Fatalf("esperado %q, obtido %q", "primeira Empresa", got)
+	}
+
+	// O template já carregado é reutilizado, mesmo que o arquivo mude
+	writeTemplate(t, dir, "relatorio.html", "segunda {{.User.CompanyName}}")
+	if got := generate(GenerateOptions{}); got != "primeira Empresa" {
+		t.Errorf("esperado o template em cache, obtido %q", got)
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if got := generate(GenerateOptions{}); got != "primeira Empresa" {
+		t.Errorf("esperado o template em cache sem reler o arquivo, obtido %q", got)
+	}
+
+	// Outro caminho é carregado separadamente
+	other := writeTemplate(t, dir, "outro.html", "outro {{.User.Username}}")
+	if got := generate(GenerateOptions{TemplatePath: other}); got != "outro Responsável" {
+		t.Errorf("esperado o template informado nas opções, obtido %q", got)
+	}
+}
+
+func TestHTMLGeneratorReportsMissingTemplate(t *testing.T) {
+	generator := NewHTMLGenerator("inexistente.html")
+
+	var out bytes.Buffer
+	err := generator.Generate(&out, sampleReportData(), GenerateOptions{})
+	if err == nil || !strings.Contains(err.Error(), "template não encontrado") {
+		t.Errorf("esperado erro de template não encontrado, obtido %v", err)
+	}
+}
@@ -1,18 +1,60 @@
 package view
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/alan-gomes1/jira-reporter/internal/model"
 )
 
+// ReportGenerator gera o relatório em um formato. Todo gerador escreve o
+// relatório completo no writer; o destino (arquivo, saída padrão, resposta
+// HTTP) é decidido por quem chama.
 type ReportGenerator interface {
-	// Generate gera o relatório no formato específico.
-	// writer: destino da saída (pode ser nil para formatos que não usam)
-	// data: dados do relatório
-	// args: argumentos adicionais específicos do formato (ex: caminhos de arquivo)
-	Generate(writer io.Writer, data *model.ReportData, args ...string) error
+	// Generate escreve o relatório no writer.
+	Generate(writer io.Writer, data *model.ReportData, opts GenerateOptions) error
 
 	// Format retorna o formato suportado pelo gerador.
 	Format() model.ReportFormat
+
+	// Extension retorna a extensão dos arquivos gerados, sem o ponto.
+	Extension() string
+
+	// MIMEType retorna o tipo de conteúdo gerado (ex: application/pdf).
+	MIMEType() string
+}
+
+// GenerateOptions são as opções de uma geração de relatório.
+type GenerateOptions struct {
+	// TemplatePath substitui o template configurado no gerador HTML
+	// (vazio usa o template do gerador).
+	TemplatePath string
+
+	// Warn recebe os avisos de uma geração concluída, que não impedem o
+	// relatório (nil descarta os avisos).
+	Warn func(Warning)
+}
+
+// Warning é um aviso de um gerador sobre o relatório gerado.
+type Warning struct {
+	Format  model.ReportFormat
+	Message string
+}
+
+// UnsupportedCharsWarning cria o aviso de caracteres sem suporte no formato,
+// substituídos por "?" no relatório.
+func UnsupportedCharsWarning(format model.ReportFormat, chars []rune) Warning {
+	return Warning{
+		Format: format,
+		Message: fmt.Sprintf(
+			"%d caractere(s) sem suporte nas fontes substituído(s) por \"?\": %s",
+			len(chars), string(chars),
+		),
+	}
+}
+
+// TeamSummaryGenerator gera o relatório consolidado do modo equipe.
+type TeamSummaryGenerator interface {
+	// Generate escreve o resumo dos relatórios gerados para a equipe.
+	Generate(writer io.Writer, summary *model.TeamSummary) error
 }
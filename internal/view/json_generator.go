@@ -0,0 +1,270 @@
+package view
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+)
+
+// JSONSchemaVersion é a versão do esquema do relatório JSON, documentado em
+// docs/report.schema.json. Mudanças incompatíveis no formato (campos
+// removidos, renomeados ou com outro tipo) exigem uma nova versão maior.
+const JSONSchemaVersion = "2.0"
+
+// Formatos das datas no relatório JSON: datas sem horário e instantes com
+// o deslocamento do fuso horário do relatório.
+const (
+	jsonDateFormat      = "2006-01-02"
+	jsonTimestampFormat = time.RFC3339
+)
+
+// jsonGenerator implementa ReportGenerator para formato JSON, serializando
+// os dados do relatório no esquema versionado.
+type jsonGenerator struct {
+	now func() time.Time
+}
+
+// NewJSONGenerator cria um novo gerador JSON.
+func NewJSONGenerator() ReportGenerator {
+	return &jsonGenerator{now: time.Now}
+}
+
+// jsonReport é o documento raiz do relatório JSON.
+type jsonReport struct {
+	SchemaVersion string       `json:"schema_version"`
+	Metadata      jsonMetadata `json:"metadata"`
+	User          jsonUser     `json:"user"`
+	Period        jsonPeriod   `json:"period"`
+	Sprint        *jsonSprint  `json:"sprint,omitempty"`
+	Issues        []jsonIssue  `json:"issues"`
+	Hours         *jsonHours   `json:"hours,omitempty"`
+	Totals        jsonTotals   `json:"totals"`
+}
+
+// jsonMetadata descreve a geração do relatório.
+type jsonMetadata struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	Generator   string    `json:"generator"`
+}
+
+// jsonUser contém os dados da empresa e do responsável.
+type jsonUser struct {
+	CompanyName string `json:"company_name"`
+	CNPJ        string `json:"cnpj"`
+	Username    string `json:"username"`
+}
+
+// jsonPeriod descreve o período do relatório.
+type jsonPeriod struct {
+	Kind  model.PeriodKind `json:"kind"`
+	Name  string           `json:"name,omitempty"`
+	Label string           `json:"label"`
+	Start string           `json:"start"`
+	End   string           `json:"end"`
+}
+
+// jsonSprint descreve a sprint do relatório (apenas no modo sprint).
+type jsonSprint struct {
+	ID      int    `json:"id"`
+	BoardID int    `json:"board_id,omitempty"`
+	Name    string `json:"name"`
+	Goal    string `json:"goal,omitempty"`
+	State   string `json:"state,omitempty"`
+	Start   string `json:"start"`
+	End     string `json:"end"`
+}
+
+// jsonIssue contém todos os campos extraídos de uma issue.
+type jsonIssue struct {
+	Key         string          `json:"key"`
+	Project     string          `json:"project"`
+	Summary     string          `json:"summary"`
+	Status      string          `json:"status,omitempty"`
+	Date        string          `json:"date,omitempty"`
+	Created     string          `json:"created,omitempty"`
+	Assigned    string          `json:"assigned,omitempty"`
+	Started     string          `json:"started,omitempty"`
+	Resolved    string          `json:"resolved,omitempty"`
+	URL         string          `json:"url"`
+	Description jsonDescription `json:"description"`
+	TimeSpent   []jsonDailyTime `json:"time_spent,omitempty"`
+	Hours       *float64        `json:"hours,omitempty"`
+}
+
+// jsonDescription traz a descrição em texto, em Markdown e estruturada.
+type jsonDescription struct {
+	Text     string        `json:"text"`
+	Markdown string        `json:"markdown"`
+	Blocks   []model.Block `json:"blocks"`
+}
+
+// jsonDailyTime é o tempo registrado em um dia.
+type jsonDailyTime struct {
+	Date    string  `json:"date"`
+	Seconds int     `json:"seconds"`
+	Hours   float64 `json:"hours"`
+}
+
+// jsonHours é o resumo das horas registradas (apenas com --hours).
+type jsonHours struct {
+	Days         []jsonDailyTime `json:"days"`
+	TotalSeconds int             `json:"total_seconds"`
+	TotalHours   float64         `json:"total_hours"`
+	HourlyRate   float64         `json:"hourly_rate,omitempty"`
+	Amount       float64         `json:"amount,omitempty"`
+}
+
+// jsonTotals resume o relatório.
+type jsonTotals struct {
+	Issues int `json:"issues"`
+}
+
+// Generate escreve o relatório em formato JSON no writer.
+func (g *jsonGenerator) Generate(
+	writer io.Writer, data *model.ReportData, opts GenerateOptions,
+) error {
+	if writer == nil {
+		return fmt.Errorf("writer não pode ser nil para geração JSON")
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(g.report(data)); err != nil {
+		return fmt.Errorf("erro ao gerar JSON: %w", err)
+	}
+	return nil
+}
+
+// Format retorna o formato suportado.
+func (g *jsonGenerator) Format() model.ReportFormat {
+	return model.FormatJSON
+}
+
+// Extension retorna a extensão dos arquivos gerados.
+func (g *jsonGenerator) Extension() string {
+	return "json"
+}
+
+// MIMEType retorna o tipo de conteúdo gerado.
+func (g *jsonGenerator) MIMEType() string {
+	return "application/json"
+}
+
+// report converte os dados do relatório no documento JSON.
+func (g *jsonGenerator) report(data *model.ReportData) jsonReport {
+	report := jsonReport{
+		SchemaVersion: JSONSchemaVersion,
+		Metadata: jsonMetadata{
+			GeneratedAt: g.now().UTC().Truncate(time.Second),
+			Generator:   "jira-reporter",
+		},
+		User: jsonUser{
+			CompanyName: data.User.CompanyName,
+			CNPJ:        data.User.CNPJ,
+			Username:    data.User.Username,
+		},
+		Period: jsonPeriod{
+			Kind:  data.Period.Kind,
+			Name:  data.Period.Name,
+			Label: data.DateWorked,
+			Start: data.Period.Start.Format(jsonDateFormat),
+			End:   data.Period.End.Format(jsonDateFormat),
+		},
+		Issues: make([]jsonIssue, 0, len(data.Jira.Items)),
+		Totals: jsonTotals{Issues: data.Jira.Count()},
+	}
+
+	if sprint := data.Sprint; sprint != nil {
+		report.Sprint = &jsonSprint{
+			ID:      sprint.ID,
+			BoardID: sprint.BoardID,
+			Name:    sprint.Name,
+			Goal:    sprint.Goal,
+			State:   sprint.State,
+			Start:   sprint.Start.Format(jsonDateFormat),
+			End:     sprint.End.Format(jsonDateFormat),
+		}
+	}
+
+	for _, issue := range data.Jira.Items {
+		item := jsonIssue{
+			Key:      issue.Key,
+			Project:  issue.Project(),
+			Summary:  issue.Summary,
+			Status:   issue.Status,
+			Date:     jsonDate(issue.Date()),
+			Created:  jsonTimestamp(issue.Created),
+			Assigned: jsonTimestamp(issue.Assigned),
+			Started:  jsonTimestamp(issue.Started),
+			Resolved: jsonTimestamp(issue.Resolved),
+			URL:      issue.URL,
+			Description: jsonDescription{
+				Text:     issue.Description.PlainText(),
+				Markdown: descriptionMarkdown(issue.Description),
+				Blocks:   issue.Description.Blocks,
+			},
+			TimeSpent: jsonDays(issue.TimeSpent),
+		}
+		if item.Description.Blocks == nil {
+			item.Description.Blocks = []model.Block{}
+		}
+		if data.Hours != nil {
+			hours := issue.Hours()
+			item.Hours = &hours
+		}
+		report.Issues = append(report.Issues, item)
+	}
+
+	if hours := data.Hours; hours != nil {
+		report.Hours = &jsonHours{
+			Days:         jsonDays(hours.Days),
+			TotalSeconds: hours.TotalSeconds,
+			TotalHours:   hours.TotalHours(),
+			HourlyRate:   hours.HourlyRate,
+		}
+		if hours.HasRate() {
+			report.Hours.Amount = hours.Amount()
+		}
+		if report.Hours.Days == nil {
+			report.Hours.Days = []jsonDailyTime{}
+		}
+	}
+	return report
+}
+
+// jsonDate formata uma data sem horário, ou retorna texto vazio quando a
+// data é desconhecida.
+func jsonDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.Format(jsonDateFormat)
+}
+
+// jsonTimestamp formata um instante com o fuso horário, ou retorna texto
+// vazio quando o instante é desconhecido.
+func jsonTimestamp(instant time.Time) string {
+	if instant.IsZero() {
+		return ""
+	}
+	return instant.Format(jsonTimestampFormat)
+}
+
+// jsonDays converte o tempo registrado por dia.
+func jsonDays(days []model.DailyTime) []jsonDailyTime {
+	if len(days) == 0 {
+		return nil
+	}
+	converted := make([]jsonDailyTime, len(days))
+	for i, day := range days {
+		converted[i] = jsonDailyTime{
+			Date:    day.Date.Format(jsonDateFormat),
+			Seconds: day.Seconds,
+			Hours:   day.Hours(),
+		}
+	}
+	return converted
+}
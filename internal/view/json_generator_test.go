@@ -0,0 +1,190 @@
+package view
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+)
+
+// updateGolden regrava os arquivos esperados em testdata (go test -update).
+var updateGolden = flag.Bool("update", false, "regrava os arquivos golden")
+
+// reportSchemaPath é o esquema documentado do relatório JSON.
+const reportSchemaPath = "../../docs/report.schema.json"
+
+// jsonTestReport gera o relatório JSON dos dados de exemplo, com sprint,
+// horas e descrição estruturada, no instante fixo informado.
+func jsonTestReport(t *testing.T, now time.Time) []byte {
+	t.Helper()
+
+	data := sampleReportData()
+	data.Sprint.BoardID = 3
+	data.Sprint.State = "closed"
+	issue := &data.Jira.Items[0]
+	issue.Status = "Concluído"
+	issue.Description = model.Description{Blocks: []model.Block{
+		{Type: model.BlockHeading, Level: 2, Inlines: []model.Inline{{Text: "Contexto"}}},
+		{Type: model.BlockParagraph, Inlines: []model.Inline{
+			{Text: "Ver "}, {Text: "guia", Link: "https://example.com/guia", Bold: true},
+		}},
+		{Type: model.BlockOrderedList, Start: 3, Items: []model.ListItem{
+			{Blocks: []model.Block{{Type: model.BlockParagraph, Inlines: []model.Inline{{Text: "passo"}}}}},
+		}},
+		{Type: model.BlockCode, Language: "go", Text: "fmt.Println()"},
+	}}
+
+	generator := &jsonGenerator{now: func() time.Time { return now }}
+	var out bytes.Buffer
+	if err := generator.Generate(&out, data, GenerateOptions{}); err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	return out.Bytes()
+}
+
+func TestJSONGeneratorGolden(t *testing.T) {
+	now := time.Date(2025, time.February, 1, 9, 30, 15, 500, time.FixedZone("BRT", -3*3600))
+	got := jsonTestReport(t, now)
+
+	golden := filepath.Join("testdata", "report.golden.json")
+	if *updateGolden {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("erro ao ler %s (use -update para gerá-lo): %v", golden, err)
+	}
+	if !bytes.Equal(got, expected) {
+		t.Errorf("JSON diferente de %s (use -update se a mudança for intencional):\n%s", golden, got)
+	}
+}
+
+func TestJSONGeneratorMatchesSchema(t *testing.T) {
+	content, err := os.ReadFile(reportSchemaPath)
+	if err != nil {
+		t.Fatalf("erro ao ler o esquema: %v", err)
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(content, &schema); err != nil {
+		t.Fatalf("esquema inválido: %v", err)
+	}
+
+	version := schema["properties"].(map[string]any)["schema_version"].(map[string]any)["const"]
+	if version != JSONSchemaVersion {
+		t.Errorf("versão do esquema %v diferente de JSONSchemaVersion %s", version, JSONSchemaVersion)
+	}
+
+	var report any
+	if err := json.Unmarshal(jsonTestReport(t, time.Now()), &report); err != nil {
+		t.Fatalf("JSON inválido: %v", err)
+	}
+	validator := schemaValidator{root: schema}
+	for _, problem := range validator.validate(schema, report, "$") {
+		t.Error(problem)
+	}
+}
+
+// schemaValidator valida um documento com o subconjunto do JSON Schema
+// usado em docs/report.schema.json. Propriedades não documentadas no
+// esquema também são apontadas, para que o esquema acompanhe o gerador.
+type schemaValidator struct {
+	root map[string]any
+}
+
+// validate retorna os problemas encontrados no valor, indicando o caminho
+// de cada um (ex: $.issues[0].key).
+func (v schemaValidator) validate(schema map[string]any, value any, path string) []string {
+	if ref, ok := schema["$ref"].(string); ok {
+		name := strings.TrimPrefix(ref, "#/$defs/")
+		return v.validate(v.root["$defs"].(map[string]any)[name].(map[string]any), value, path)
+	}
+
+	var problems []string
+	fail := func(format string, args ...any) {
+		problems = append(problems, path+": "+fmt.Sprintf(format, args...))
+	}
+
+	if expected, ok := schema["const"]; ok && value != expected {
+		fail("esperado %v, obtido %v", expected, value)
+	}
+	if enum, ok := schema["enum"].([]any); ok && !slices.Contains(enum, value) {
+		fail("valor %v fora de %v", value, enum)
+	}
+
+	switch schema["type"] {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			fail("esperado objeto, obtido %T", value)
+			break
+		}
+		required, _ := schema["required"].([]any)
+		for _, name := range required {
+			if _, exists := object[name.(string)]; !exists {
+				fail("campo obrigatório %s ausente", name)
+			}
+		}
+		properties, _ := schema["properties"].(map[string]any)
+		for name, field := range object {
+			property, documented := properties[name].(map[string]any)
+			if !documented {
+				fail("campo %s não documentado no esquema", name)
+				continue
+			}
+			problems = append(problems, v.validate(property, field, path+"."+name)...)
+		}
+	case "array":
+		list, ok := value.([]any)
+		if !ok {
+			fail("esperada lista, obtido %T", value)
+			break
+		}
+		items := schema["items"].(map[string]any)
+		for i, item := range list {
+			problems = append(problems, v.validate(items, item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			fail("esperado texto, obtido %T", value)
+			break
+		}
+		format, _ := schema["format"].(string)
+		layouts := map[string]string{"date": time.DateOnly, "date-time": time.RFC3339}
+		if layout, ok := layouts[format]; ok {
+			if _, err := time.Parse(layout, text); err != nil {
+				fail("formato %s inválido: %q", format, text)
+			}
+		}
+	case "integer", "number":
+		number, ok := value.(float64)
+		if !ok {
+			fail("esperado número, obtido %T", value)
+			break
+		}
+		if schema["type"] == "integer" && number != float64(int64(number)) {
+			fail("esperado inteiro, obtido %v", number)
+		}
+		if minimum, ok := schema["minimum"].(float64); ok && number < minimum {
+			fail("valor %v menor que %v", number, minimum)
+		}
+		if maximum, ok := schema["maximum"].(float64); ok && number > maximum {
+			fail("valor %v maior que %v", number, maximum)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			fail("esperado booleano, obtido %T", value)
+		}
+	}
+	return problems
+}
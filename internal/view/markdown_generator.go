@@ -0,0 +1,165 @@
+package view
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+)
+
+// markdownGenerator implementa ReportGenerator para formato Markdown, pronto
+// para ser colado no Confluence ou no GitHub.
+type markdownGenerator struct{}
+
+// NewMarkdownGenerator cria um novo gerador Markdown.
+func NewMarkdownGenerator() ReportGenerator {
+	return &markdownGenerator{}
+}
+
+// Generate escreve o relatório em formato Markdown no writer.
+func (g *markdownGenerator) Generate(
+	writer io.Writer, data *model.ReportData, opts GenerateOptions,
+) error {
+	if writer == nil {
+		return fmt.Errorf("writer não pode ser nil para geração Markdown")
+	}
+
+	w := bufio.NewWriter(writer)
+	fmt.Fprintln(w, "# RELATÓRIO DE PRESTAÇÃO DE SERVIÇOS")
+	fmt.Fprintln(w)
+	writeMarkdownHeader(w, data)
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "## ATIVIDADES")
+	fmt.Fprintln(w)
+	writeMarkdownActivities(w, data)
+
+	if data.Hours != nil {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "## HORAS TRABALHADAS")
+		fmt.Fprintln(w)
+		writeMarkdownHours(w, data.Hours, data.Period)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "## RESUMO DAS ATIVIDADES")
+	writeMarkdownSummary(w, data)
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("erro ao gerar Markdown: %w", err)
+	}
+	return nil
+}
+
+// Format retorna o formato suportado.
+func (g *markdownGenerator) Format() model.ReportFormat {
+	return model.FormatMarkdown
+}
+
+// Extension retorna a extensão dos arquivos gerados.
+func (g *markdownGenerator) Extension() string {
+	return "md"
+}
+
+// MIMEType retorna o tipo de conteúdo gerado.
+func (g *markdownGenerator) MIMEType() string {
+	return "text/markdown; charset=utf-8"
+}
+
+// writeMarkdownHeader escreve os dados da empresa e do período.
+func writeMarkdownHeader(w io.Writer, data *model.ReportData) {
+	for _, row := range headerRows(data) {
+		fmt.Fprintf(w, "- **%s:** %s\n", row[0], markdownEscaper.Replace(row[1]))
+	}
+}
+
+// writeMarkdownActivities escreve a tabela de atividades com as chaves das
+// issues como links. Quando há horas registradas, inclui a coluna de horas.
+func writeMarkdownActivities(w io.Writer, data *model.ReportData) {
+	header := []string{"DATA", "ID DA TAREFA", "ATIVIDADE"}
+	if data.Hours != nil {
+		header = append(header, "HORAS")
+	}
+	writeMarkdownRow(w, header)
+	writeMarkdownRow(w, markdownSeparator(len(header)))
+
+	for _, issue := range data.Jira.Items {
+		row := []string{
+			formatDay(issue.Date()),
+			markdownIssueLink(issue),
+			markdownCell(issue.Summary),
+		}
+		if data.Hours != nil {
+			row = append(row, formatHours(issue.Hours()))
+		}
+		writeMarkdownRow(w, row)
+	}
+}
+
+// writeMarkdownHours escreve as horas trabalhadas por dia, o total do
+// período e, quando há valor da hora configurado, o valor a faturar.
+func writeMarkdownHours(
+	w io.Writer, hours *model.HoursSummary, period model.Period,
+) {
+	writeMarkdownRow(w, []string{"DIA", "HORAS TRABALHADAS"})
+	writeMarkdownRow(w, markdownSeparator(2))
+	for _, day := range hours.Days {
+		writeMarkdownRow(w, []string{formatDay(day.Date), formatHours(day.Hours())})
+	}
+	writeMarkdownRow(w, []string{
+		"**" + periodTotalLabel(period) + "**",
+		"**" + formatHours(hours.TotalHours()) + "**",
+	})
+	if hours.HasRate() {
+		writeMarkdownRow(w, []string{
+			"**VALOR DA HORA**", formatCurrency(hours.HourlyRate),
+		})
+		writeMarkdownRow(w, []string{
+			"**VALOR TOTAL**", "**" + formatCurrency(hours.Amount()) + "**",
+		})
+	}
+}
+
+// writeMarkdownSummary escreve a descrição de cada issue convertida para
+// Markdown, com a chave da issue como título.
+func writeMarkdownSummary(w io.Writer, data *model.ReportData) {
+	for _, issue := range data.Jira.Items {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "### %s\n", markdownIssueLink(issue))
+
+		if description := descriptionMarkdown(issue.Description); description != "" {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, description)
+		}
+	}
+}
+
+// markdownIssueLink retorna a chave da issue com link para o Jira.
+func markdownIssueLink(issue model.Issue) string {
+	if issue.URL == "" {
+		return issue.Key
+	}
+	return fmt.Sprintf("[%s](%s)", issue.Key, markdownURL(issue.URL))
+}
+
+// markdownCell escapa o texto de uma célula de tabela, que precisa ficar em
+// uma única linha.
+func markdownCell(text string) string {
+	return strings.Join(strings.Fields(markdownEscaper.Replace(text)), " ")
+}
+
+// markdownSeparator retorna a linha separadora do cabeçalho da tabela.
+func markdownSeparator(columns int) []string {
+	separator := make([]string, columns)
+	for i := range separator {
+		separator[i] = "---"
+	}
+	return separator
+}
+
+// writeMarkdownRow escreve uma linha de tabela Markdown.
+func writeMarkdownRow(w io.Writer, cells []string) {
+	fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | "))
+}
@@ -0,0 +1,47 @@
+package view
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+)
+
+func TestMarkdownGeneratorOutput(t *testing.T) {
+	data := sampleReportData()
+	issue := &data.Jira.Items[0]
+	issue.Summary = "Corrigir | tabela\n*quebrada*"
+	issue.URL = "https://jira.example.com/browse/PROJ-1?x=(1)"
+	issue.Description = model.Description{Blocks: []model.Block{{
+		Type: model.BlockParagraph,
+		Inlines: []model.Inline{
+			{Text: "Veja "},
+			{Text: "o guia", Link: "https://example.com/guia (v2)"},
+			{Text: " e rode "},
+			{Text: "echo `date`", Code: true},
+		},
+	}}}
+
+	var out bytes.Buffer
+	if err := NewMarkdownGenerator().Generate(&out, data, GenerateOptions{}); err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	got := out.String()
+
+	link := "[PROJ-1](https://jira.example.com/browse/PROJ-1?x=%281%29)"
+	expected := []string{
+		"# RELATÓRIO DE PRESTAÇÃO DE SERVIÇOS\n",
+		"| DATA | ID DA TAREFA | ATIVIDADE | HORAS |\n| --- | --- | --- | --- |\n",
+		"| 06/01 | " + link + ` | Corrigir \| tabela \*quebrada\* | 1,00 |` + "\n",
+		"## HORAS TRABALHADAS\n",
+		"| **VALOR TOTAL** | **R$ 100,00** |\n",
+		"### " + link + "\n\n" +
+			"Veja [o guia](https://example.com/guia%20%28v2%29) e rode `` echo `date` ``\n",
+	}
+	for _, fragment := range expected {
+		if !strings.Contains(got, fragment) {
+			t.Errorf("esperado %q no Markdown:\n%s", fragment, got)
+		}
+	}
+}
@@ -0,0 +1,513 @@
+package view
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+	"github.com/go-pdf/fpdf"
+)
+
+// Dimensões do layout do PDF, em milímetros.
+const (
+	pdfMargin        = 15.0
+	pdfLineHeight    = 5.5
+	pdfCellPadding   = 2.0
+	pdfHeaderLabelW  = 55.0
+	pdfListIndent    = 6.0
+	pdfSignatureGap  = 40.0
+	pdfFontFamily    = "Arial"
+	pdfCodeFamily    = "Courier"
+	pdfBaseFontSize  = 11.0
+	pdfTitleFontSize = 12.0
+)
+
+// pdfHeaderGray é a cor de fundo das células de cabeçalho (#CCCCCC).
+var pdfHeaderGray = [3]int{0xCC, 0xCC, 0xCC}
+
+// pdfGenerator implementa ReportGenerator para formato PDF, gerando o
+// documento em Go puro com o mesmo layout do template HTML.
+type pdfGenerator struct{}
+
+// NewPDFGenerator cria um novo gerador PDF.
+func NewPDFGenerator() ReportGenerator {
+	return &pdfGenerator{}
+}
+
+// Generate escreve o relatório em formato PDF no writer.
+func (g *pdfGenerator) Generate(
+	writer io.Writer, data *model.ReportData, opts GenerateOptions,
+) error {
+	if writer == nil {
+		return fmt.Errorf("writer não pode ser nil para geração PDF")
+	}
+
+	doc := newPDFDocument()
+	doc.writeReport(data)
+
+	if err := doc.pdf.Output(writer); err != nil {
+		return fmt.Errorf("erro ao gerar PDF: %w", err)
+	}
+
+	if len(doc.unsupported) > 0 && opts.Warn != nil {
+		opts.Warn(UnsupportedCharsWarning(g.Format(), doc.unsupported))
+	}
+	return nil
+}
+
+// Format retorna o formato suportado.
+func (g *pdfGenerator) Format() model.ReportFormat {
+	return model.FormatPDF
+}
+
+// Extension retorna a extensão dos arquivos gerados.
+func (g *pdfGenerator) Extension() string {
+	return "pdf"
+}
+
+// MIMEType retorna o tipo de conteúdo gerado.
+func (g *pdfGenerator) MIMEType() string {
+	return "application/pdf"
+}
+
+// pdfFallbacks substitui símbolos comuns ausentes no cp1252 por texto
+// equivalente.
+var pdfFallbacks = map[rune]string{
+	'→': "->", '←': "<-", '⇒': "=>", '↔': "<->",
+	'≤': "<=", '≥': ">=", '≠': "!=", '−': "-",
+	'✓': "v", '✔': "v", '✗': "x", '✘': "x",
+}
+
+// pdfDocument encapsula o documento fpdf e a conversão de texto para a
+// codificação das fontes padrão (cp1252).
+type pdfDocument struct {
+	pdf         *fpdf.Fpdf
+	cp1252      func(string) string
+	unsupported []rune  // Caracteres sem representação no cp1252
+	width       float64 // Largura útil da página
+}
+
+// newPDFDocument cria um documento A4 com rodapé numerado.
+func newPDFDocument() *pdfDocument {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin+5)
+	pdf.AliasNbPages("")
+
+	doc := &pdfDocument{
+		pdf:    pdf,
+		cp1252: pdf.UnicodeTranslatorFromDescriptor(""),
+	}
+	pageWidth, _ := pdf.GetPageSize()
+	doc.width = pageWidth - 2*pdfMargin
+
+	pdf.SetTitle("Relatório de Prestação de Serviços", true)
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pdfMargin)
+		pdf.SetFont(pdfFontFamily, "I", 8)
+		pdf.CellFormat(0, 10, doc.tr(fmt.Sprintf(
+			"Página %d de {nb}", pdf.PageNo(),
+		)), "", 0, "C", false, 0, "")
+	})
+
+	pdf.AddPage()
+	pdf.SetFont(pdfFontFamily, "", pdfBaseFontSize)
+	return doc
+}
+
+// tr converte o texto para o cp1252 das fontes padrão. Caracteres sem
+// representação (ex: CJK, emoji) que não têm equivalente em pdfFallbacks
+// são substituídos por "?" e registrados em unsupported.
+func (d *pdfDocument) tr(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		if r < utf8.RuneSelf {
+			b.WriteRune(r)
+			continue
+		}
+		// O tradutor do fpdf converte caracteres desconhecidos em "."
+		if converted := d.cp1252(string(r)); converted != "." {
+			b.WriteString(converted)
+			continue
+		}
+		if fallback, ok := pdfFallbacks[r]; ok {
+			b.WriteString(fallback)
+			continue
+		}
+		if !slices.Contains(d.unsupported, r) {
+			d.unsupported = append(d.unsupported, r)
+		}
+		b.WriteByte('?')
+	}
+	return b.String()
+}
+
+// writeReport escreve o conteúdo do relatório reproduzindo o template HTML.
+func (d *pdfDocument) writeReport(data *model.ReportData) {
+	d.writeHeaderTable(data)
+
+	d.pdf.Ln(pdfLineHeight * 2)
+	d.pdf.SetFont(pdfFontFamily, "B", pdfTitleFontSize)
+	d.pdf.CellFormat(
+		0, pdfLineHeight, d.tr("RELATÓRIO DE PRESTAÇÃO DE SERVIÇOS"),
+		"", 1, "C", false, 0, "",
+	)
+	d.pdf.Ln(pdfLineHeight)
+
+	d.writeActivityTable(data)
+
+	if data.Hours != nil {
+		d.pdf.Ln(pdfLineHeight * 2)
+		d.writeHoursTable(data.Hours, data.Period)
+	}
+
+	d.pdf.Ln(pdfLineHeight * 2)
+	d.writeSummary(data)
+
+	d.writeSignature()
+}
+
+// writeHeaderTable escreve a tabela com os dados da empresa.
+func (d *pdfDocument) writeHeaderTable(data *model.ReportData) {
+	type headerRow struct {
+		label  string
+		value  string
+		italic bool
+	}
+	rows := []headerRow{
+		{"RAZÃO SOCIAL", data.User.CompanyName, true},
+		{"CNPJ", data.User.CNPJ, true},
+		{"RESPONSÁVEL LEGAL", data.User.Username, true},
+		{"PROJETO", "GOVONE", false},
+		{periodTitle(data.Period), data.DateWorked, false},
+	}
+	if data.Sprint != nil && data.Sprint.Goal != "" {
+		rows = append(rows, headerRow{"OBJETIVO DA SPRINT", data.Sprint.Goal, false})
+	}
+
+	valueWidth := d.width - pdfHeaderLabelW
+	for _, row := range rows {
+		valueStyle := ""
+		if row.italic {
+			valueStyle = "I"
+		}
+		d.tableRow([]pdfCell{
+			{Text: row.label, Width: pdfHeaderLabelW, Style: "B", Fill: true},
+			{Text: row.value, Width: valueWidth, Style: valueStyle},
+		}, nil)
+	}
+}
+
+// writeActivityTable escreve a tabela de atividades com links clicáveis.
+// Quando há horas registradas, inclui a coluna de horas por tarefa.
+func (d *pdfDocument) writeActivityTable(data *model.ReportData) {
+	widths := []float64{d.width * 0.10, d.width * 0.15, d.width * 0.75}
+	if data.Hours != nil {
+		widths = []float64{
+			d.width * 0.10, d.width * 0.15, d.width * 0.65, d.width * 0.10,
+		}
+	}
+
+	header := []pdfCell{
+		{Text: "DATA", Width: widths[0], Style: "B", Fill: true},
+		{Text: "ID DA TAREFA", Width: widths[1], Style: "B", Fill: true},
+		{Text: "ATIVIDADE", Width: widths[2], Style: "B", Fill: true},
+	}
+	if data.Hours != nil {
+		header = append(header, pdfCell{
+			Text: "HORAS", Width: widths[3], Style: "B", Fill: true,
+		})
+	}
+
+	d.tableRow(header, nil)
+	for _, issue := range data.Jira.Items {
+		cells := []pdfCell{
+			{Text: formatDay(issue.Date()), Width: widths[0]},
+			{Text: issue.Key, Width: widths[1], Link: issue.URL},
+			{Text: issue.Summary, Width: widths[2]},
+		}
+		if data.Hours != nil {
+			cells = append(cells, pdfCell{
+				Text: formatHours(issue.Hours()), Width: widths[3],
+			})
+		}
+		d.tableRow(cells, header)
+	}
+}
+
+// writeHoursTable escreve as horas trabalhadas por dia, o total do período
+// e, quando há valor da hora configurado, o valor a faturar.
+func (d *pdfDocument) writeHoursTable(
+	hours *model.HoursSummary, period model.Period,
+) {
+	widths := []float64{d.width * 0.25, d.width * 0.75}
+	header := []pdfCell{
+		{Text: "DIA", Width: widths[0], Style: "B", Fill: true},
+		{Text: "HORAS TRABALHADAS", Width: widths[1], Style: "B", Fill: true},
+	}
+
+	d.tableRow(header, nil)
+	for _, day := range hours.Days {
+		d.tableRow([]pdfCell{
+			{Text: formatDay(day.Date), Width: widths[0]},
+			{Text: formatHours(day.Hours()), Width: widths[1]},
+		}, header)
+	}
+	d.tableRow([]pdfCell{
+		{Text: periodTotalLabel(period), Width: widths[0], Style: "B", Fill: true},
+		{Text: formatHours(hours.TotalHours()), Width: widths[1], Style: "B", Fill: true},
+	}, header)
+	if hours.HasRate() {
+		d.tableRow([]pdfCell{
+			{Text: "VALOR DA HORA", Width: widths[0], Style: "B"},
+			{Text: formatCurrency(hours.HourlyRate), Width: widths[1]},
+		}, header)
+		d.tableRow([]pdfCell{
+			{Text: "VALOR TOTAL", Width: widths[0], Style: "B", Fill: true},
+			{Text: formatCurrency(hours.Amount()), Width: widths[1], Style: "B", Fill: true},
+		}, header)
+	}
+}
+
+// writeSummary escreve o resumo com a descrição de cada issue.
+func (d *pdfDocument) writeSummary(data *model.ReportData) {
+	d.tableRow([]pdfCell{{
+		Text: "RESUMO DAS ATIVIDADES", Width: d.width, Style: "B", Fill: true,
+	}}, nil)
+	d.pdf.Ln(pdfCellPadding)
+
+	for _, issue := range data.Jira.Items {
+		d.pdf.SetFont(pdfFontFamily, "B", pdfBaseFontSize)
+		d.pdf.Write(pdfLineHeight, d.tr(issue.Key+": "))
+		d.pdf.SetFont(pdfFontFamily, "", pdfBaseFontSize)
+
+		d.writeBlocks(issue.Description.Blocks, pdfMargin, true)
+		d.pdf.Ln(pdfLineHeight + pdfCellPadding)
+	}
+}
+
+// writeSignature escreve a área de assinatura, mantendo-a inteira na
+// mesma página.
+func (d *pdfDocument) writeSignature() {
+	_, pageHeight := d.pdf.GetPageSize()
+	_, bottomMargin := d.pdf.GetAutoPageBreak()
+	needed := pdfSignatureGap + pdfLineHeight*3
+	if d.pdf.GetY()+needed > pageHeight-bottomMargin {
+		d.pdf.AddPage()
+		d.pdf.Ln(pdfLineHeight * 2)
+	} else {
+		d.pdf.Ln(pdfSignatureGap)
+	}
+
+	d.pdf.SetFont(pdfFontFamily, "B", pdfBaseFontSize)
+	d.pdf.CellFormat(
+		0, pdfLineHeight, "___________________________", "", 1, "C", false, 0, "",
+	)
+	d.pdf.Ln(pdfCellPadding)
+	d.pdf.CellFormat(
+		0, pdfLineHeight, d.tr("RESPONSÁVEL LEGAL(ASSINAR COM GOV.BR)"),
+		"", 1, "C", false, 0, "",
+	)
+}
+
+// writeBlocks escreve os blocos da descrição a partir da margem left.
+// inline indica que o primeiro parágrafo continua na linha atual.
+func (d *pdfDocument) writeBlocks(
+	blocks []model.Block, left float64, inline bool,
+) {
+	for i, block := range blocks {
+		if i > 0 || !inline {
+			d.newLine(left)
+		}
+
+		switch block.Type {
+		case model.BlockParagraph:
+			d.writeInlines(block.Inlines, left)
+		case model.BlockHeading:
+			d.pdf.SetFont(pdfFontFamily, "B", pdfBaseFontSize)
+			d.pdf.Write(pdfLineHeight, d.tr(model.InlinesPlainText(block.Inlines)))
+			d.pdf.SetFont(pdfFontFamily, "", pdfBaseFontSize)
+		case model.BlockCode:
+			d.pdf.SetFont(pdfCodeFamily, "", pdfBaseFontSize-1)
+			for j, line := range strings.Split(block.Text, "\n") {
+				if j > 0 {
+					d.newLine(left + pdfListIndent)
+				}
+				d.pdf.SetX(left + pdfListIndent)
+				d.pdf.Write(pdfLineHeight, d.tr(line))
+			}
+			d.pdf.SetFont(pdfFontFamily, "", pdfBaseFontSize)
+		case model.BlockQuote:
+			d.writeBlocks(block.Children, left+pdfListIndent, true)
+		case model.BlockRule:
+			d.pdf.Write(pdfLineHeight, "---")
+		case model.BlockBulletList, model.BlockOrderedList:
+			for j, item := range block.Items {
+				if j > 0 {
+					d.newLine(left)
+				}
+				marker := "• "
+				if block.Type == model.BlockOrderedList {
+					marker = fmt.Sprintf("%d. ", block.ListStart()+j)
+				}
+				d.pdf.SetX(left + pdfListIndent)
+				d.pdf.Write(pdfLineHeight, d.tr(marker))
+				d.writeBlocks(item.Blocks, left+pdfListIndent, true)
+			}
+		}
+	}
+}
+
+// writeInlines escreve trechos de texto com estilo e links clicáveis.
+func (d *pdfDocument) writeInlines(inlines []model.Inline, left float64) {
+	d.pdf.SetLeftMargin(left)
+	defer d.pdf.SetLeftMargin(pdfMargin)
+
+	for _, inline := range inlines {
+		if inline.Break {
+			d.pdf.Ln(pdfLineHeight)
+			continue
+		}
+
+		family := pdfFontFamily
+		if inline.Code {
+			family = pdfCodeFamily
+		}
+		style := ""
+		if inline.Bold {
+			style += "B"
+		}
+		if inline.Italic {
+			style += "I"
+		}
+		link := safeLink(inline.Link)
+		if inline.Underline || link != "" {
+			style += "U"
+		}
+		d.pdf.SetFont(family, style, pdfBaseFontSize)
+
+		if link != "" {
+			d.pdf.SetTextColor(0x05, 0x63, 0xC1)
+			d.pdf.WriteLinkString(pdfLineHeight, d.tr(inline.Text), link)
+			d.pdf.SetTextColor(0, 0, 0)
+		} else {
+			d.pdf.Write(pdfLineHeight, d.tr(inline.Text))
+		}
+	}
+	d.pdf.SetFont(pdfFontFamily, "", pdfBaseFontSize)
+}
+
+// newLine avança uma linha e posiciona o cursor na margem left.
+func (d *pdfDocument) newLine(left float64) {
+	d.pdf.Ln(pdfLineHeight)
+	d.pdf.SetX(left)
+}
+
+// pdfCell descreve uma célula de tabela.
+type pdfCell struct {
+	Text  string
+	Width float64
+	Style string // Estilo da fonte ("", "B", "I")
+	Fill  bool   // Fundo cinza de cabeçalho
+	Link  string // URL externa da célula
+}
+
+// tableRow escreve uma linha de tabela com quebra automática do texto.
+// Quando a linha não cabe na página, uma nova página é iniciada e a linha
+// de cabeçalho repeatHeader (se informada) é repetida. Uma linha mais alta
+// que a página é dividida entre as páginas seguintes.
+func (d *pdfDocument) tableRow(cells []pdfCell, repeatHeader []pdfCell) {
+	lines := make([][]string, len(cells))
+	maxLines := 1
+	for i, cell := range cells {
+		d.pdf.SetFont(pdfFontFamily, cell.Style, pdfBaseFontSize)
+		for _, line := range d.pdf.SplitLines(
+			[]byte(d.tr(cell.Text)), cell.Width-2*pdfCellPadding,
+		) {
+			lines[i] = append(lines[i], string(line))
+		}
+		maxLines = max(maxLines, len(lines[i]))
+	}
+
+	_, top, _, _ := d.pdf.GetMargins()
+	pageLines := d.linesFrom(top)
+	for first := 0; first < maxLines; {
+		remaining := maxLines - first
+		fit := d.linesFrom(d.pdf.GetY())
+		// Só divide a linha que não caberia inteira em uma nova página
+		if fit < remaining && (fit < 1 || (first == 0 && remaining <= pageLines)) {
+			d.newTablePage(repeatHeader)
+			fit = d.linesFrom(d.pdf.GetY())
+		}
+
+		count := min(max(fit, 1), remaining)
+		d.drawRow(cells, lines, first, count)
+		first += count
+		if first < maxLines {
+			d.newTablePage(repeatHeader)
+		}
+	}
+
+	d.pdf.SetFont(pdfFontFamily, "", pdfBaseFontSize)
+}
+
+// linesFrom retorna quantas linhas de texto de uma célula cabem entre a
+// posição y e a margem inferior da página.
+func (d *pdfDocument) linesFrom(y float64) int {
+	_, pageHeight := d.pdf.GetPageSize()
+	_, bottomMargin := d.pdf.GetAutoPageBreak()
+	available := pageHeight - bottomMargin - y - 2*pdfCellPadding
+	return int(available / pdfLineHeight)
+}
+
+// newTablePage inicia uma nova página repetindo a linha de cabeçalho da
+// tabela, quando informada.
+func (d *pdfDocument) newTablePage(repeatHeader []pdfCell) {
+	d.pdf.AddPage()
+	if repeatHeader != nil {
+		d.tableRow(repeatHeader, nil)
+	}
+}
+
+// drawRow desenha as células com count linhas de texto a partir da linha
+// first e posiciona o cursor abaixo delas.
+func (d *pdfDocument) drawRow(cells []pdfCell, lines [][]string, first, count int) {
+	height := float64(count)*pdfLineHeight + 2*pdfCellPadding
+
+	x, y := d.pdf.GetXY()
+	for i, cell := range cells {
+		if cell.Fill {
+			d.pdf.SetFillColor(pdfHeaderGray[0], pdfHeaderGray[1], pdfHeaderGray[2])
+			d.pdf.Rect(x, y, cell.Width, height, "FD")
+		} else {
+			d.pdf.Rect(x, y, cell.Width, height, "D")
+		}
+
+		style := cell.Style
+		if cell.Link != "" {
+			style += "U"
+			d.pdf.SetTextColor(0x05, 0x63, 0xC1)
+		}
+		d.pdf.SetFont(pdfFontFamily, style, pdfBaseFontSize)
+		cellLines := lines[i][min(first, len(lines[i])):min(first+count, len(lines[i]))]
+		for j, line := range cellLines {
+			d.pdf.SetXY(
+				x+pdfCellPadding, y+pdfCellPadding+float64(j)*pdfLineHeight,
+			)
+			d.pdf.CellFormat(
+				cell.Width-2*pdfCellPadding, pdfLineHeight, line,
+				"", 0, "L", false, 0, "",
+			)
+		}
+		if cell.Link != "" {
+			d.pdf.LinkString(x, y, cell.Width, height, cell.Link)
+			d.pdf.SetTextColor(0, 0, 0)
+		}
+		x += cell.Width
+	}
+
+	d.pdf.SetXY(pdfMargin, y+height)
+}
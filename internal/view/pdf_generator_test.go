@@ -0,0 +1,123 @@
+package view
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+)
+
+// pdfTextOperator captura a posição e o texto dos operadores de texto do
+// conteúdo das páginas (sem compressão).
+var pdfTextOperator = regexp.MustCompile(`BT ([\d.]+) (-?[\d.]+) Td \((.*?)\) ?Tj ET`)
+
+func TestPDFTranslatesUnsupportedCharacters(t *testing.T) {
+	doc := newPDFDocument()
+
+	got := doc.tr("ação → 日本 😀 日")
+	expected := "a\xe7\xe3o -> ?? ? ?"
+	if got != expected {
+		t.Errorf("esperado %q, obtido %q", expected, got)
+	}
+	if string(doc.unsupported) != "日本😀" {
+		t.Errorf("caracteres sem suporte esperados %q, obtido %q", "日本😀", string(doc.unsupported))
+	}
+}
+
+func TestPDFKeepsLongContentInsidePages(t *testing.T) {
+	var markers []string
+	for i := 1; i <= 1500; i++ {
+		markers = append(markers, fmt.Sprintf("M%04d", i))
+	}
+
+	data := sampleReportData()
+	issue := &data.Jira.Items[0]
+	// Resumo com mais linhas do que cabem em uma página
+	issue.Summary = "中文 " + strings.Join(markers, " ")
+	var paragraphs []string
+	for i := 0; i < 120; i++ {
+		paragraphs = append(paragraphs, fmt.Sprintf("Parágrafo D%03d com 日本語 → fim", i))
+	}
+	issue.Description = model.NewTextDescription(strings.Join(paragraphs, "\n\n"))
+
+	doc := newPDFDocument()
+	doc.pdf.SetCompression(false)
+	doc.writeReport(data)
+	var out bytes.Buffer
+	if err := doc.pdf.Output(&out); err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	// Uma linha desenhada além da quebra faz o fpdf abrir uma página por
+	// linha de texto, inflando o total de páginas
+	if pages := doc.pdf.PageNo(); pages < 3 || pages > 12 {
+		t.Errorf("esperadas de 3 a 12 páginas, obtido %d", pages)
+	}
+
+	_, pageHeight := doc.pdf.GetPageSize()
+	_, bottomMargin := doc.pdf.GetAutoPageBreak()
+	scale := doc.pdf.GetConversionRatio()
+	minY, maxY := bottomMargin*scale, (pageHeight-pdfMargin)*scale
+
+	var text strings.Builder
+	for _, match := range pdfTextOperator.FindAllStringSubmatch(out.String(), -1) {
+		if strings.Contains(match[3], "gina ") {
+			continue // Rodapé com o número da página
+		}
+		y, _ := strconv.ParseFloat(match[2], 64)
+		if y < minY || y > maxY {
+			t.Errorf("texto %q fora da área da página (y=%.2f)", match[3], y)
+		}
+		text.WriteString(match[3] + " ")
+	}
+
+	words := strings.Fields(text.String())
+	for _, marker := range markers {
+		if count := strings.Count(" "+text.String(), " "+marker+" "); count != 1 {
+			t.Errorf("marcador %s do resumo encontrado %d vez(es)", marker, count)
+		}
+	}
+	for i := 0; i < 120; i++ {
+		if !slices.Contains(words, fmt.Sprintf("D%03d", i)) {
+			t.Errorf("parágrafo D%03d da descrição não encontrado", i)
+		}
+	}
+	if !slices.Contains(words, "->") {
+		t.Error("seta não substituída por ->")
+	}
+	if string(doc.unsupported) != "中文日本語" {
+		t.Errorf("caracteres sem suporte inesperados: %q", string(doc.unsupported))
+	}
+}
+
+func TestPDFGeneratorReportsUnsupportedCharactersToCaller(t *testing.T) {
+	data := sampleReportData()
+	data.Jira.Items[0].Summary = "Revisão 日本 → ok"
+
+	var warnings []Warning
+	opts := GenerateOptions{Warn: func(warning Warning) {
+		warnings = append(warnings, warning)
+	}}
+	var out bytes.Buffer
+	if err := NewPDFGenerator().Generate(&out, data, opts); err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	if len(warnings) != 1 {
+		t.Fatalf("esperado 1 aviso, obtido %v", warnings)
+	}
+	if warnings[0].Format != model.FormatPDF || !strings.Contains(warnings[0].Message, "2 caractere(s)") ||
+		!strings.Contains(warnings[0].Message, "日本") {
+		t.Errorf("aviso inesperado: %+v", warnings[0])
+	}
+
+	// Sem Warn, o aviso é descartado e o PDF é gerado normalmente
+	out.Reset()
+	if err := NewPDFGenerator().Generate(&out, data, GenerateOptions{}); err != nil || out.Len() == 0 {
+		t.Errorf("esperado PDF gerado sem Warn, obtido %d bytes (erro: %v)", out.Len(), err)
+	}
+}
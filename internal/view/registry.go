@@ -0,0 +1,43 @@
+package view
+
+import "github.com/alan-gomes1/jira-reporter/internal/model"
+
+// Registry reúne os geradores de relatório disponíveis, indexados pelo
+// formato. Novos formatos são adicionados registrando o gerador, sem
+// alterações no serviço de relatórios.
+type Registry struct {
+	generators map[model.ReportFormat]ReportGenerator
+	formats    []model.ReportFormat // Formatos na ordem de registro
+}
+
+// NewRegistry cria um registro com os geradores informados.
+func NewRegistry(generators ...ReportGenerator) *Registry {
+	registry := &Registry{
+		generators: map[model.ReportFormat]ReportGenerator{},
+	}
+	for _, generator := range generators {
+		registry.Register(generator)
+	}
+	return registry
+}
+
+// Register adiciona o gerador ao registro, substituindo o gerador já
+// registrado para o mesmo formato.
+func (r *Registry) Register(generator ReportGenerator) {
+	format := generator.Format()
+	if _, exists := r.generators[format]; !exists {
+		r.formats = append(r.formats, format)
+	}
+	r.generators[format] = generator
+}
+
+// Get retorna o gerador do formato.
+func (r *Registry) Get(format model.ReportFormat) (ReportGenerator, bool) {
+	generator, exists := r.generators[format]
+	return generator, exists
+}
+
+// Formats retorna os formatos registrados, na ordem de registro.
+func (r *Registry) Formats() []model.ReportFormat {
+	return append([]model.ReportFormat(nil), r.formats...)
+}
@@ -0,0 +1,40 @@
+package view
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+)
+
+func TestRegistryKeepsOrderAndReplacesFormat(t *testing.T) {
+	csv := NewCSVGenerator(false)
+	registry := NewRegistry(NewHTMLGenerator(""), csv, NewJSONGenerator())
+
+	// Um novo gerador de um formato já registrado substitui o anterior
+	// sem mudar a ordem
+	bom := NewCSVGenerator(true)
+	registry.Register(bom)
+	registry.Register(NewMarkdownGenerator())
+
+	expected := []model.ReportFormat{
+		model.FormatHTML, model.FormatCSV, model.FormatJSON, model.FormatMarkdown,
+	}
+	formats := registry.Formats()
+	if !slices.Equal(formats, expected) {
+		t.Errorf("formatos esperados %v, obtido %v", expected, formats)
+	}
+
+	if generator, exists := registry.Get(model.FormatCSV); !exists || generator != bom {
+		t.Errorf("esperado o último gerador CSV registrado, obtido %v", generator)
+	}
+	if _, exists := registry.Get(model.FormatPDF); exists {
+		t.Error("formato PDF não registrado encontrado")
+	}
+
+	// A lista retornada é uma cópia
+	formats[0] = model.FormatPDF
+	if registry.Formats()[0] != model.FormatHTML {
+		t.Error("alterar a lista retornada não deveria alterar o registro")
+	}
+}
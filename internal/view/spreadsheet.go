@@ -0,0 +1,71 @@
+package view
+
+import (
+	"strconv"
+
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+)
+
+// sheetValue é o valor de uma célula de planilha: texto ou número.
+type sheetValue struct {
+	Text     string
+	Number   float64
+	IsNumber bool
+}
+
+// textValue cria uma célula de texto.
+func textValue(text string) sheetValue {
+	return sheetValue{Text: text}
+}
+
+// numberValue cria uma célula numérica.
+func numberValue(number float64) sheetValue {
+	return sheetValue{Number: number, IsNumber: true}
+}
+
+// activityTable retorna o cabeçalho e as linhas da lista de atividades usada
+// nas exportações para planilhas: uma linha por issue, com as horas quando
+// o relatório inclui os worklogs.
+func activityTable(data *model.ReportData) ([]string, [][]sheetValue) {
+	header := []string{"DATA", "ID DA TAREFA", "ATIVIDADE", "STATUS", "URL"}
+	if data.Hours != nil {
+		header = append(header, "HORAS")
+	}
+
+	rows := make([][]sheetValue, 0, len(data.Jira.Items))
+	for _, issue := range data.Jira.Items {
+		row := []sheetValue{
+			textValue(formatDate(issue.Date())),
+			textValue(issue.Key),
+			textValue(issue.Summary),
+			textValue(issue.Status),
+			textValue(issue.URL),
+		}
+		if data.Hours != nil {
+			row = append(row, numberValue(issue.Hours()))
+		}
+		rows = append(rows, row)
+	}
+	return header, rows
+}
+
+// metadataRows retorna os dados do relatório exibidos junto da lista de
+// atividades: o cabeçalho do relatório e os totais.
+func metadataRows(data *model.ReportData) [][2]string {
+	rows := headerRows(data)
+	rows = append(rows, [2]string{
+		"TOTAL DE ATIVIDADES", strconv.Itoa(data.Jira.Count()),
+	})
+	if data.Hours != nil {
+		rows = append(rows, [2]string{
+			periodTotalLabel(data.Period), formatHours(data.Hours.TotalHours()),
+		})
+		if data.Hours.HasRate() {
+			rows = append(rows,
+				[2]string{"VALOR DA HORA", formatCurrency(data.Hours.HourlyRate)},
+				[2]string{"VALOR TOTAL", formatCurrency(data.Hours.Amount())},
+			)
+		}
+	}
+	return rows
+}
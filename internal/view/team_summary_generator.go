@@ -0,0 +1,100 @@
+package view
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"path/filepath"
+
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+)
+
+// teamSummaryTemplate é o template do relatório consolidado da equipe,
+// com o mesmo estilo do relatório individual.
+const teamSummaryTemplate = `<!DOCTYPE html>
+<html>
+
+<head>
+    <meta charset="utf-8">
+    <title>Resumo da Equipe</title>
+</head>
+
+<body style="font-family: Arial, sans-serif; font-size: 11pt;">
+
+    <table border="1" cellpadding="7" cellspacing="0" width="100%">
+        <tr>
+            <td width="140" bgcolor="#CCCCCC"><b>{{periodTitle .Period}}</b></td>
+            <td bgcolor="#FFFFFF">{{.DateWorked}}</td>
+        </tr>
+        {{if and .Sprint .Sprint.Goal}}
+        <tr>
+            <td bgcolor="#CCCCCC"><b>OBJETIVO DA SPRINT</b></td>
+            <td bgcolor="#FFFFFF">{{.Sprint.Goal}}</td>
+        </tr>
+        {{end}}
+    </table>
+
+    <br><br>
+
+    <p align="center" style="font-size: 12pt;"><b>RESUMO DA EQUIPE</b></p>
+
+    <br>
+
+    <table border="1" cellpadding="8" cellspacing="0" width="100%">
+        <tr bgcolor="#CCCCCC">
+            <td><b>RESPONSÁVEL LEGAL</b></td>
+            <td><b>RAZÃO SOCIAL</b></td>
+            <td><b>CNPJ</b></td>
+            <td><b>ISSUES</b></td>
+            {{if .HasHours}}<td><b>HORAS</b></td>{{end}}
+            <td><b>RELATÓRIO</b></td>
+        </tr>
+        {{range .Members}}
+        <tr>
+            <td>{{.Member.User.Username}}</td>
+            <td><i>{{.Member.User.CompanyName}}</i></td>
+            <td><i>{{.Member.User.CNPJ}}</i></td>
+            <td>{{.Issues}}</td>
+            {{if $.HasHours}}<td>{{with .Hours}}{{hours .TotalHours}}{{end}}</td>{{end}}
+            <td>{{range .Files}}<a href="{{base .}}">{{base .}}</a><br>{{end}}{{if .Failed}}Falha: {{.Error}}{{end}}</td>
+        </tr>
+        {{end}}
+        <tr bgcolor="#CCCCCC">
+            <td colspan="3"><b>TOTAL</b></td>
+            <td><b>{{.TotalIssues}}</b></td>
+            {{if .HasHours}}<td><b>{{hours .TotalHours}}</b></td>{{end}}
+            <td></td>
+        </tr>
+    </table>
+
+</body>
+
+</html>
+`
+
+// teamSummaryGenerator implementa TeamSummaryGenerator em HTML.
+type teamSummaryGenerator struct {
+	tmpl *template.Template
+}
+
+// NewTeamSummaryGenerator cria o gerador do relatório consolidado da equipe.
+func NewTeamSummaryGenerator() TeamSummaryGenerator {
+	funcs := templateFuncs()
+	funcs["base"] = filepath.Base
+
+	return &teamSummaryGenerator{
+		tmpl: template.Must(
+			template.New("team").Funcs(funcs).Parse(teamSummaryTemplate),
+		),
+	}
+}
+
+// Generate escreve o resumo dos relatórios gerados para a equipe.
+func (g *teamSummaryGenerator) Generate(
+	writer io.Writer, summary *model.TeamSummary,
+) error {
+	if err := g.tmpl.Execute(writer, summary); err != nil {
+		return fmt.Errorf("erro ao gerar o resumo da equipe: %w", err)
+	}
+	return nil
+}
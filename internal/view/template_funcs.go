@@ -0,0 +1,236 @@
+package view
+
+import (
+	"fmt"
+	"html/template"
+	"reflect"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+)
+
+// defaultDateLayout é o formato padrão de formatDate (ex: 05/01/2025).
+const defaultDateLayout = "02/01/2006"
+
+// Nomes dos meses e dias da semana em português, usados por formatDate.
+var (
+	monthNames = []string{
+		"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho",
+		"agosto", "setembro", "outubro", "novembro", "dezembro",
+	}
+	weekdayNames = []string{
+		"domingo", "segunda-feira", "terça-feira", "quarta-feira",
+		"quinta-feira", "sexta-feira", "sábado",
+	}
+)
+
+// templateFuncs retorna as funções disponíveis nos templates.
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"description":  descriptionHTML,
+		"markdown":     descriptionMarkdown, // Texto-fonte, escapado no HTML
+		"markdownText": descriptionMarkdown, // Sinônimo de markdown
+		"hours":        formatHours,
+		"currency":     formatCurrency,
+		"day":          formatDay,
+		"formatDate":   formatDate,
+		"periodTitle":  periodTitle,
+		"periodTotal":  periodTotalLabel,
+		"upper":        strings.ToUpper,
+		"lower":        strings.ToLower,
+		"truncate":     truncate,
+		"count":        count,
+		"sum":          sum,
+		"groupBy":      groupBy,
+	}
+}
+
+// formatDate formata a data em português. O layout segue o padrão do Go
+// (padrão: 02/01/2006); nomes de meses e dias da semana são traduzidos
+// (ex: "02 de January de 2006" resulta em "05 de janeiro de 2025"). Datas
+// desconhecidas (instante zero) resultam em texto vazio.
+func formatDate(date time.Time, layout ...string) string {
+	if date.IsZero() {
+		return ""
+	}
+	format := defaultDateLayout
+	if len(layout) > 0 && layout[0] != "" {
+		format = layout[0]
+	}
+
+	// Os nomes em inglês são trocados por marcadores antes da formatação
+	// para que não se confundam com o restante do texto
+	month := monthNames[date.Month()-1]
+	weekday := weekdayNames[date.Weekday()]
+	replacements := []struct {
+		token  string
+		marker string
+		value  string
+	}{
+		{"January", "\x00M\x00", month},
+		{"Jan", "\x00m\x00", abbreviate(month)},
+		{"Monday", "\x00W\x00", weekday},
+		{"Mon", "\x00w\x00", abbreviate(weekday)},
+	}
+	for _, r := range replacements {
+		format = strings.ReplaceAll(format, r.token, r.marker)
+	}
+
+	formatted := date.Format(format)
+	for _, r := range replacements {
+		formatted = strings.ReplaceAll(formatted, r.marker, r.value)
+	}
+	return formatted
+}
+
+// abbreviate retorna as três primeiras letras do nome.
+func abbreviate(name string) string {
+	return string([]rune(name)[:3])
+}
+
+// truncate limita o texto a max caracteres, terminando com reticências.
+func truncate(max int, text string) string {
+	if max <= 0 || utf8.RuneCountInString(text) <= max {
+		return text
+	}
+	return strings.TrimSpace(string([]rune(text)[:max])) + "…"
+}
+
+// count retorna a quantidade de itens de uma lista, mapa ou coleção de
+// issues (ex: {{count .Jira}}).
+func count(items any) (int, error) {
+	if collection, ok := items.(model.IssueCollection); ok {
+		return collection.Count(), nil
+	}
+	if collection, ok := items.(*model.IssueCollection); ok {
+		return collection.Count(), nil
+	}
+
+	value := reflect.ValueOf(items)
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return value.Len(), nil
+	case reflect.Invalid:
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("count: tipo não suportado %T", items)
+	}
+}
+
+// sum soma o campo (ou método) numérico informado em cada item da lista
+// (ex: {{sum "Hours" .Jira.Items}}).
+func sum(field string, items any) (float64, error) {
+	list, err := listValues(items)
+	if err != nil {
+		return 0, fmt.Errorf("sum: %w", err)
+	}
+
+	total := 0.0
+	for _, item := range list {
+		value, err := fieldValue(item, field)
+		if err != nil {
+			return 0, fmt.Errorf("sum: %w", err)
+		}
+		switch value.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			total += float64(value.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			total += float64(value.Uint())
+		case reflect.Float32, reflect.Float64:
+			total += value.Float()
+		default:
+			return 0, fmt.Errorf("sum: o campo %s não é numérico", field)
+		}
+	}
+	return total, nil
+}
+
+// templateGroup é um grupo de itens com o mesmo valor de campo.
+type templateGroup struct {
+	Key   string
+	Items []any
+}
+
+// Count retorna a quantidade de itens do grupo.
+func (g templateGroup) Count() int {
+	return len(g.Items)
+}
+
+// groupBy agrupa os itens da lista pelo valor do campo (ou método)
+// informado, mantendo a ordem da primeira ocorrência de cada grupo
+// (ex: {{range groupBy "Project" .Jira.Items}}).
+func groupBy(field string, items any) ([]templateGroup, error) {
+	list, err := listValues(items)
+	if err != nil {
+		return nil, fmt.Errorf("groupBy: %w", err)
+	}
+
+	var groups []templateGroup
+	index := map[string]int{}
+	for _, item := range list {
+		value, err := fieldValue(item, field)
+		if err != nil {
+			return nil, fmt.Errorf("groupBy: %w", err)
+		}
+
+		key := fmt.Sprint(value.Interface())
+		position, exists := index[key]
+		if !exists {
+			position = len(groups)
+			index[key] = position
+			groups = append(groups, templateGroup{Key: key})
+		}
+		groups[position].Items = append(groups[position].Items, item.Interface())
+	}
+	return groups, nil
+}
+
+// listValues converte uma lista (ou coleção de issues) nos seus itens.
+func listValues(items any) ([]reflect.Value, error) {
+	if collection, ok := items.(model.IssueCollection); ok {
+		items = collection.Items
+	}
+	if collection, ok := items.(*model.IssueCollection); ok {
+		items = collection.Items
+	}
+
+	value := reflect.ValueOf(items)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, fmt.Errorf("esperada uma lista, recebido %T", items)
+	}
+
+	list := make([]reflect.Value, value.Len())
+	for i := range list {
+		list[i] = value.Index(i)
+	}
+	return list, nil
+}
+
+// fieldValue retorna o valor do campo ou método sem argumentos do item.
+func fieldValue(item reflect.Value, name string) (reflect.Value, error) {
+	for item.Kind() == reflect.Interface || item.Kind() == reflect.Pointer {
+		if item.IsNil() {
+			return reflect.Value{}, fmt.Errorf("item nulo")
+		}
+		item = item.Elem()
+	}
+
+	method := item.MethodByName(name)
+	if !method.IsValid() && item.CanAddr() {
+		method = item.Addr().MethodByName(name)
+	}
+	if method.IsValid() &&
+		method.Type().NumIn() == 0 && method.Type().NumOut() == 1 {
+		return method.Call(nil)[0], nil
+	}
+	if item.Kind() == reflect.Struct {
+		if field := item.FieldByName(name); field.IsValid() {
+			return field, nil
+		}
+	}
+	return reflect.Value{}, fmt.Errorf(
+		"campo ou método %s não existe em %s", name, item.Type(),
+	)
+}
@@ -0,0 +1,183 @@
+package view
+
+import (
+	"bytes"
+	"html/template"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+)
+
+// testIssues retorna issues de dois projetos com horas registradas.
+func testIssues() *model.IssueCollection {
+	day := time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC)
+	issues := model.NewIssueCollection()
+	for _, item := range []struct {
+		key     string
+		seconds int
+	}{{"ABC-1", 3600}, {"XYZ-1", 1800}, {"ABC-2", 5400}} {
+		issue := model.Issue{Key: item.key}
+		issue.TimeSpent = model.AddTimeSpent(nil, day, item.seconds)
+		issues.Add(issue)
+	}
+	return issues
+}
+
+func TestFormatDate(t *testing.T) {
+	date := time.Date(2025, time.March, 5, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		layout   []string
+		expected string
+	}{
+		{nil, "05/03/2025"},
+		{[]string{""}, "05/03/2025"},
+		{[]string{"02 de January de 2006"}, "05 de março de 2025"},
+		{[]string{"Jan/06"}, "mar/25"},
+		{[]string{"Monday, 02/01"}, "quarta-feira, 05/03"},
+		{[]string{"Mon 15:04"}, "qua 14:30"},
+	}
+	for _, tt := range tests {
+		if got := formatDate(date, tt.layout...); got != tt.expected {
+			t.Errorf("layout %q: esperado %q, obtido %q", tt.layout, tt.expected, got)
+		}
+	}
+
+	if got := formatDate(time.Time{}); got != "" {
+		t.Errorf("data zero: esperado texto vazio, obtido %q", got)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		max      int
+		text     string
+		expected string
+	}{
+		{10, "curto", "curto"},
+		{5, "exato", "exato"},
+		{5, "ação longa", "ação…"},
+		{6, "texto final", "texto…"}, // O espaço antes das reticências é removido
+		{0, "sem limite", "sem limite"},
+	}
+	for _, tt := range tests {
+		if got := truncate(tt.max, tt.text); got != tt.expected {
+			t.Errorf("truncate(%d, %q): esperado %q, obtido %q", tt.max, tt.text, tt.expected, got)
+		}
+	}
+}
+
+func TestCount(t *testing.T) {
+	issues := testIssues()
+
+	tests := []struct {
+		name     string
+		items    any
+		expected int
+	}{
+		{"coleção", *issues, 3},
+		{"ponteiro para coleção", issues, 3},
+		{"lista", []string{"a", "b"}, 2},
+		{"mapa", map[string]int{"a": 1}, 1},
+		{"nulo", nil, 0},
+	}
+	for _, tt := range tests {
+		got, err := count(tt.items)
+		if err != nil || got != tt.expected {
+			t.Errorf("%s: esperado %d, obtido %d (erro: %v)", tt.name, tt.expected, got, err)
+		}
+	}
+
+	if _, err := count(42); err == nil {
+		t.Error("esperado erro para tipo não suportado")
+	}
+}
+
+func TestSum(t *testing.T) {
+	issues := testIssues()
+
+	got, err := sum("Hours", issues.Items)
+	if err != nil || got != 3 {
+		t.Errorf("Hours: esperado 3, obtido %v (erro: %v)", got, err)
+	}
+	got, err = sum("TimeSpentSeconds", issues)
+	if err != nil || got != 10800 {
+		t.Errorf("TimeSpentSeconds: esperado 10800, obtido %v (erro: %v)", got, err)
+	}
+
+	errors := []struct {
+		field string
+		items any
+		err   string
+	}{
+		{"Key", issues.Items, "não é numérico"},
+		{"Inexistente", issues.Items, "campo ou método Inexistente não existe"},
+		{"Hours", 42, "esperada uma lista"},
+	}
+	for _, tt := range errors {
+		if _, err := sum(tt.field, tt.items); err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("sum %s: esperado erro contendo %q, obtido %v", tt.field, tt.err, err)
+		}
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	groups, err := groupBy("Project", testIssues())
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	// Os grupos mantêm a ordem da primeira ocorrência
+	expected := []struct {
+		key  string
+		keys []string
+	}{
+		{"ABC", []string{"ABC-1", "ABC-2"}},
+		{"XYZ", []string{"XYZ-1"}},
+	}
+	if len(groups) != len(expected) {
+		t.Fatalf("esperados %d grupos, obtido %d", len(expected), len(groups))
+	}
+	for i, group := range groups {
+		if group.Key != expected[i].key || group.Count() != len(expected[i].keys) {
+			t.Errorf("grupo %d: esperado %s com %d itens, obtido %s com %d",
+				i, expected[i].key, len(expected[i].keys), group.Key, group.Count())
+			continue
+		}
+		for j, item := range group.Items {
+			if key := item.(model.Issue).Key; key != expected[i].keys[j] {
+				t.Errorf("grupo %s: item %d esperado %s, obtido %s", group.Key, j, expected[i].keys[j], key)
+			}
+		}
+	}
+
+	if _, err := groupBy("Inexistente", testIssues()); err == nil {
+		t.Error("esperado erro para campo inexistente")
+	}
+}
+
+func TestMarkdownIsEscapedInHTML(t *testing.T) {
+	description := model.NewTextDescription("<b>negrito</b>")
+	// O Markdown é exibido como texto, sem ser interpretado pelo navegador
+	expected := "<pre>" + template.HTMLEscapeString(descriptionMarkdown(description)) + "</pre>"
+
+	for _, name := range []string{"markdown", "markdownText"} {
+		library, err := loadTemplateLibrary("")
+		if err != nil {
+			t.Fatalf("erro inesperado: %v", err)
+		}
+		if err := library.parse(mainTemplateName, "<pre>{{"+name+" .}}</pre>"); err != nil {
+			t.Fatalf("%s: erro inesperado: %v", name, err)
+		}
+
+		var out bytes.Buffer
+		if err := library.execute(&out, description); err != nil {
+			t.Fatalf("%s: erro inesperado: %v", name, err)
+		}
+		if out.String() != expected || strings.Contains(out.String(), "<b>") {
+			t.Errorf("%s: esperado %q, obtido %q", name, expected, out.String())
+		}
+	}
+}
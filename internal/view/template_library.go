@@ -0,0 +1,231 @@
+package view
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+)
+
+// embeddedTemplates contém o template padrão do relatório e seus parciais,
+// embutidos no binário e usados quando nenhum template externo é encontrado.
+//
+//go:embed templates
+var embeddedTemplates embed.FS
+
+// Nomes e extensões usados na biblioteca de templates.
+const (
+	embeddedTemplatesDir = "templates"
+	mainTemplateName     = "report.html" // Template principal do relatório
+	partialsDirName      = "partials"    // Diretório dos parciais
+)
+
+// templateExtensions são as extensões dos arquivos carregados como templates.
+var templateExtensions = []string{".html", ".tmpl"}
+
+// templateErrorPattern identifica o arquivo e a linha nas mensagens de erro
+// do html/template (ex: "template: report.html:12:5: ...").
+var templateErrorPattern = regexp.MustCompile(
+	`^(?:html/)?template: ?([^:]+):(\d+)(?::\d+)?: (.*)$`,
+)
+
+// templateLibrary é o conjunto de templates carregado: o template principal,
+// os parciais e o código-fonte de cada arquivo (para as mensagens de erro).
+type templateLibrary struct {
+	tmpl    *template.Template
+	sources map[string]string
+}
+
+// loadTemplateLibrary carrega os templates do relatório. Os templates
+// embutidos são sempre carregados primeiro e podem ser redefinidos por:
+//   - um arquivo: usado como template principal, junto com os parciais do
+//     diretório partials/ ao lado dele, se existir;
+//   - um diretório: report.html (opcional) e os demais arquivos .html/.tmpl,
+//     incluindo subdiretórios como partials/.
+func loadTemplateLibrary(templatePath string) (*templateLibrary, error) {
+	library := &templateLibrary{
+		tmpl:    template.New("").Funcs(templateFuncs()),
+		sources: map[string]string{},
+	}
+
+	embedded, err := fs.Sub(embeddedTemplates, embeddedTemplatesDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := library.parseFS(embedded); err != nil {
+		return nil, err
+	}
+
+	if templatePath == "" {
+		return library, nil
+	}
+
+	info, err := os.Stat(templatePath)
+	if err != nil {
+		return nil, fmt.Errorf("template não encontrado: %w", err)
+	}
+	if info.IsDir() {
+		if err := library.parseFS(os.DirFS(templatePath)); err != nil {
+			return nil, err
+		}
+		return library, nil
+	}
+
+	content, err := os.ReadFile(templatePath)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler template %s: %w", templatePath, err)
+	}
+	if err := library.parse(mainTemplateName, string(content)); err != nil {
+		return nil, err
+	}
+
+	partials := filepath.Join(filepath.Dir(templatePath), partialsDirName)
+	if info, err := os.Stat(partials); err == nil && info.IsDir() {
+		err := library.parseFSWithPrefix(os.DirFS(partials), partialsDirName)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return library, nil
+}
+
+// parseFS carrega todos os templates do sistema de arquivos.
+func (l *templateLibrary) parseFS(fsys fs.FS) error {
+	return l.parseFSWithPrefix(fsys, "")
+}
+
+// parseFSWithPrefix carrega os templates do sistema de arquivos, nomeando
+// cada um pelo caminho relativo precedido do prefixo informado.
+func (l *templateLibrary) parseFSWithPrefix(fsys fs.FS, prefix string) error {
+	return fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !isTemplateFile(name) {
+			return nil
+		}
+
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return fmt.Errorf("erro ao ler template %s: %w", name, err)
+		}
+		return l.parse(path.Join(prefix, name), string(content))
+	})
+}
+
+// parse adiciona o template à biblioteca, guardando o código-fonte para
+// apontar a linha em caso de erro.
+func (l *templateLibrary) parse(name, content string) error {
+	l.sources[name] = content
+	if _, err := l.tmpl.New(name).Parse(content); err != nil {
+		return l.explain(err)
+	}
+	return nil
+}
+
+// execute executa o template principal com os dados do relatório.
+func (l *templateLibrary) execute(writer io.Writer, data any) error {
+	if err := l.tmpl.ExecuteTemplate(writer, mainTemplateName, data); err != nil {
+		return l.explain(err)
+	}
+	return nil
+}
+
+// explain reescreve o erro do html/template indicando o arquivo, a linha e
+// o trecho do template em que o problema ocorreu.
+func (l *templateLibrary) explain(err error) error {
+	match := templateErrorPattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return err
+	}
+
+	name, message := match[1], match[3]
+	line, _ := strconv.Atoi(match[2])
+
+	source := ""
+	if lines := strings.Split(l.sources[name], "\n"); line > 0 && line <= len(lines) {
+		source = fmt.Sprintf("\n    %d | %s", line, strings.TrimSpace(lines[line-1]))
+	}
+	return fmt.Errorf(
+		"template %s, linha %d: %w%s",
+		name, line, templateCause{message: message, err: err}, source,
+	)
+}
+
+// templateCause é o erro original do html/template exibido sem o prefixo
+// com o arquivo e a linha, já informados por explain.
+type templateCause struct {
+	message string
+	err     error
+}
+
+func (c templateCause) Error() string {
+	return c.message
+}
+
+func (c templateCause) Unwrap() error {
+	return c.err
+}
+
+// isTemplateFile verifica se o arquivo tem uma extensão de template.
+func isTemplateFile(name string) bool {
+	ext := strings.ToLower(path.Ext(name))
+	for _, templateExt := range templateExtensions {
+		if ext == templateExt {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateTemplates carrega os templates e os executa com dados de exemplo,
+// para que erros de sintaxe, funções ou campos inexistentes sejam apontados
+// na inicialização, antes da busca no Jira.
+func ValidateTemplates(templatePath string) error {
+	library, err := loadTemplateLibrary(templatePath)
+	if err != nil {
+		return err
+	}
+	return library.execute(io.Discard, sampleReportData())
+}
+
+// sampleReportData monta dados de exemplo que percorrem todas as seções do
+// template padrão (horas, valor da hora e sprint).
+func sampleReportData() *model.ReportData {
+	day := time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC)
+
+	issue := model.NewIssue(
+		"PROJ-1", "Exemplo", model.NewTextDescription("Descrição de exemplo"),
+		day, "https://example.atlassian.net/browse/PROJ-1",
+	)
+	issue.Assigned = day.Add(time.Hour)
+	issue.Started = day.Add(2 * time.Hour)
+	issue.Resolved = day.AddDate(0, 0, 1)
+	issue.TimeSpent = model.AddTimeSpent(nil, day, 3600)
+
+	issues := model.NewIssueCollection()
+	issues.Add(*issue)
+
+	sprint := &model.Sprint{
+		ID: 1, Name: "Sprint 1", Goal: "Objetivo",
+		Start: day, End: day.AddDate(0, 0, 13),
+	}
+
+	data := model.NewReportData(
+		*model.NewUser("Empresa", "00.000.000/0001-00", "Responsável"),
+		*issues, model.NewMonthPeriod(1, 2025),
+	)
+	data.Sprint = sprint
+	data.Hours = model.NewHoursSummary(*issues, 100)
+	return data
+}
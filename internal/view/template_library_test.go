@@ -0,0 +1,132 @@
+package view
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	texttemplate "text/template"
+)
+
+// writeTemplate grava o template no diretório informado.
+func writeTemplate(t *testing.T, dir, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestTemplateErrorsPointToLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected []string // Trechos esperados do erro, na ordem
+	}{
+		{
+			name: "sintaxe",
+			files: map[string]string{
+				"report.html": "<p>início</p>\n<p>{{.User.CompanyName}}</p>\n<p>{{end}}</p>\n",
+			},
+			expected: []string{"template report.html, linha 3:", "\n    3 | <p>{{end}}</p>"},
+		},
+		{
+			name: "função inexistente",
+			files: map[string]string{
+				"report.html": "<p>início</p>\n\n  {{upper .User.CompanyName | negrito}}\n",
+			},
+			expected: []string{"template report.html, linha 3:", "negrito", "\n    3 | {{upper .User.CompanyName | negrito}}"},
+		},
+		{
+			name: "campo inexistente na execução",
+			files: map[string]string{
+				"report.html": "<p>{{.User.CompanyName}}</p>\n<p>{{.Inexistente}}</p>\n",
+			},
+			expected: []string{"template report.html, linha 2:", "Inexistente", "\n    2 | <p>{{.Inexistente}}</p>"},
+		},
+		{
+			name: "erro em parcial",
+			files: map[string]string{
+				"report.html":         `{{template "extra" .}}`,
+				"partials/extra.tmpl": "{{define \"extra\"}}\n<p>{{sum \"Key\" .Jira.Items}}</p>\n{{end}}",
+			},
+			expected: []string{"template partials/extra.tmpl, linha 2:", "não é numérico", "\n    2 | <p>{{sum \"Key\" .Jira.Items}}</p>"},
+		},
+	}
+	for _, tt := range tests {
+		dir := t.TempDir()
+		for name, content := range tt.files {
+			writeTemplate(t, dir, name, content)
+		}
+
+		err := ValidateTemplates(dir)
+		if err == nil {
+			t.Errorf("%s: esperado erro, obtido nenhum", tt.name)
+			continue
+		}
+		message := err.Error()
+		position := 0
+		for _, fragment := range tt.expected {
+			index := strings.Index(message[position:], fragment)
+			if index < 0 {
+				t.Errorf("%s: esperado %q em %q", tt.name, fragment, message)
+				break
+			}
+			position += index + len(fragment)
+		}
+	}
+}
+
+func TestTemplateErrorsKeepOriginalError(t *testing.T) {
+	library, err := loadTemplateLibrary("")
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if err := library.parse(mainTemplateName, "<p>\n{{.Inexistente}}</p>"); err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	err = library.execute(io.Discard, sampleReportData())
+	var execErr texttemplate.ExecError
+	if !errors.As(err, &execErr) {
+		t.Fatalf("esperado o erro original do template encadeado, obtido %#v", err)
+	}
+	if !strings.HasPrefix(err.Error(), "template report.html, linha 2: executing") {
+		t.Errorf("mensagem inesperada: %q", err.Error())
+	}
+}
+
+func TestTemplateFileUsesSiblingPartials(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTemplate(t, dir, "relatorio.tmpl", `{{template "extra" .}}`)
+	writeTemplate(t, dir, "partials/extra.html", `{{define "extra"}}{{.User.CompanyName}}{{end}}`)
+
+	library, err := loadTemplateLibrary(path)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	var out strings.Builder
+	if err := library.execute(&out, sampleReportData()); err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if out.String() != "Empresa" {
+		t.Errorf("esperado %q, obtido %q", "Empresa", out.String())
+	}
+}
+
+func TestEmbeddedTemplatesRenderSampleData(t *testing.T) {
+	library, err := loadTemplateLibrary("")
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if err := library.execute(io.Discard, sampleReportData()); err != nil {
+		t.Errorf("erro inesperado: %v", err)
+	}
+}
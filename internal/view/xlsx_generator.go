@@ -0,0 +1,244 @@
+package view
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/alan-gomes1/jira-reporter/internal/model"
+)
+
+// Partes fixas do pacote SpreadsheetML.
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+<Override PartName="/xl/worksheets/sheet2.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+<Override PartName="/docProps/core.xml" ContentType="application/vnd.openxmlformats-package.core-properties+xml"/>
+</Types>`
+
+	xlsxPackageRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties" Target="docProps/core.xml"/>
+</Relationships>`
+
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets>
+<sheet name="Atividades" sheetId="1" r:id="rId1"/>
+<sheet name="Metadados" sheetId="2" r:id="rId2"/>
+</sheets>
+</workbook>`
+
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet2.xml"/>
+<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`
+
+	// Estilos: 0 = padrão, 1 = cabeçalho (negrito com fundo cinza),
+	// 2 = número com duas casas decimais
+	xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<fonts count="2"><font><sz val="11"/><name val="Arial"/></font><font><b/><sz val="11"/><name val="Arial"/></font></fonts>
+<fills count="3"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill><fill><patternFill patternType="solid"><fgColor rgb="FFCCCCCC"/></patternFill></fill></fills>
+<borders count="1"><border/></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="3">
+<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>
+<xf numFmtId="0" fontId="1" fillId="2" borderId="0" xfId="0" applyFont="1" applyFill="1"/>
+<xf numFmtId="2" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>
+</cellXfs>
+</styleSheet>`
+
+	xlsxCoreProps = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/">
+<dc:title>Relatório de Prestação de Serviços</dc:title>
+</cp:coreProperties>`
+
+	xlsxHeaderStyle = 1
+	xlsxNumberStyle = 2
+)
+
+// Larguras das colunas, em caracteres.
+var (
+	xlsxActivityWidths = []int{12, 16, 60, 18, 45, 10}
+	xlsxMetadataWidths = []int{28, 60}
+)
+
+// xlsxGenerator implementa ReportGenerator escrevendo o pacote XLSX
+// (SpreadsheetML) diretamente, com a planilha "Atividades" (uma linha por
+// issue) e a planilha "Metadados" (empresa, CNPJ, período e totais).
+type xlsxGenerator struct{}
+
+// NewXLSXGenerator cria um novo gerador XLSX.
+func NewXLSXGenerator() ReportGenerator {
+	return &xlsxGenerator{}
+}
+
+// Generate escreve o relatório em formato XLSX no writer.
+func (g *xlsxGenerator) Generate(
+	writer io.Writer, data *model.ReportData, opts GenerateOptions,
+) error {
+	if writer == nil {
+		return fmt.Errorf("writer não pode ser nil para geração XLSX")
+	}
+
+	header, rows := activityTable(data)
+	activities := newXLSXSheet(xlsxActivityWidths[:len(header)])
+	activities.headerRow(header)
+	for _, row := range rows {
+		activities.row(row)
+	}
+
+	metadata := newXLSXSheet(xlsxMetadataWidths)
+	for _, row := range metadataRows(data) {
+		metadata.cells([]xlsxCell{
+			{value: textValue(row[0]), style: xlsxHeaderStyle},
+			{value: textValue(row[1])},
+		})
+	}
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxPackageRels},
+		{"docProps/core.xml", xlsxCoreProps},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+		{"xl/worksheets/sheet1.xml", activities.xml()},
+		{"xl/worksheets/sheet2.xml", metadata.xml()},
+	}
+
+	archive := zip.NewWriter(writer)
+	for _, part := range parts {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return fmt.Errorf("erro ao gerar XLSX: %w", err)
+		}
+		if _, err := io.WriteString(file, part.content); err != nil {
+			return fmt.Errorf("erro ao gerar XLSX: %w", err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("erro ao gerar XLSX: %w", err)
+	}
+	return nil
+}
+
+// Format retorna o formato suportado.
+func (g *xlsxGenerator) Format() model.ReportFormat {
+	return model.FormatXLSX
+}
+
+// Extension retorna a extensão dos arquivos gerados.
+func (g *xlsxGenerator) Extension() string {
+	return "xlsx"
+}
+
+// MIMEType retorna o tipo de conteúdo gerado.
+func (g *xlsxGenerator) MIMEType() string {
+	return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+}
+
+// xlsxCell é uma célula com o seu estilo.
+type xlsxCell struct {
+	value sheetValue
+	style int
+}
+
+// xlsxSheet acumula as linhas de uma planilha.
+type xlsxSheet struct {
+	widths []int
+	rows   strings.Builder
+	count  int
+}
+
+// newXLSXSheet cria uma planilha vazia com as larguras de coluna informadas.
+func newXLSXSheet(widths []int) *xlsxSheet {
+	return &xlsxSheet{widths: widths}
+}
+
+// headerRow adiciona uma linha de cabeçalho.
+func (s *xlsxSheet) headerRow(header []string) {
+	cells := make([]xlsxCell, len(header))
+	for i, text := range header {
+		cells[i] = xlsxCell{value: textValue(text), style: xlsxHeaderStyle}
+	}
+	s.cells(cells)
+}
+
+// row adiciona uma linha de valores, formatando os números com duas casas.
+func (s *xlsxSheet) row(values []sheetValue) {
+	cells := make([]xlsxCell, len(values))
+	for i, value := range values {
+		cells[i] = xlsxCell{value: value}
+		if value.IsNumber {
+			cells[i].style = xlsxNumberStyle
+		}
+	}
+	s.cells(cells)
+}
+
+// cells adiciona uma linha com as células informadas.
+func (s *xlsxSheet) cells(cells []xlsxCell) {
+	s.count++
+	fmt.Fprintf(&s.rows, `<row r="%d">`, s.count)
+	for i, cell := range cells {
+		ref := xlsxColumnName(i) + strconv.Itoa(s.count)
+		style := ""
+		if cell.style != 0 {
+			style = fmt.Sprintf(` s="%d"`, cell.style)
+		}
+
+		if cell.value.IsNumber {
+			fmt.Fprintf(&s.rows, `<c r="%s"%s><v>%s</v></c>`, ref, style,
+				strconv.FormatFloat(cell.value.Number, 'f', -1, 64))
+			continue
+		}
+		fmt.Fprintf(&s.rows,
+			`<c r="%s"%s t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`,
+			ref, style, escapeXML(cell.value.Text))
+	}
+	s.rows.WriteString(`</row>`)
+}
+
+// xml serializa a planilha.
+func (s *xlsxSheet) xml() string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	b.WriteString(`<cols>`)
+	for i, width := range s.widths {
+		fmt.Fprintf(&b, `<col min="%d" max="%d" width="%d" customWidth="1"/>`,
+			i+1, i+1, width)
+	}
+	b.WriteString(`</cols>`)
+	b.WriteString(`<sheetData>`)
+	b.WriteString(s.rows.String())
+	b.WriteString(`</sheetData>`)
+	b.WriteString(`</worksheet>`)
+	return b.String()
+}
+
+// xlsxColumnName converte o índice da coluna (a partir de 0) na letra
+// usada nas referências de célula (0 = A, 26 = AA).
+func xlsxColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
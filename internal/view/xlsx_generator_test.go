@@ -0,0 +1,110 @@
+package view
+
+import (
+	"bytes"
+	"encoding/xml"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// xlsxWorksheet é a estrutura lida das planilhas geradas.
+type xlsxWorksheet struct {
+	Rows []struct {
+		Number int `xml:"r,attr"`
+		Cells  []struct {
+			Ref    string `xml:"r,attr"`
+			Type   string `xml:"t,attr"`
+			Style  int    `xml:"s,attr"`
+			Value  string `xml:"v"`
+			Inline string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// texts retorna o conteúdo de cada linha da planilha, com os números como
+// aparecem no XML.
+func (s xlsxWorksheet) texts() [][]string {
+	rows := make([][]string, len(s.Rows))
+	for i, row := range s.Rows {
+		for _, cell := range row.Cells {
+			rows[i] = append(rows[i], cell.Inline+cell.Value)
+		}
+	}
+	return rows
+}
+
+func TestXLSXGeneratorSheets(t *testing.T) {
+	data := sampleReportData()
+	data.Jira.Items[0].Summary = "=SOMA(1;2) & <b>"
+	data.Jira.Items[0].Status = "Concluído"
+
+	var out bytes.Buffer
+	if err := NewXLSXGenerator().Generate(&out, data, GenerateOptions{}); err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	archive := openZip(t, out.Bytes())
+
+	var activities, metadata xlsxWorksheet
+	if err := xml.Unmarshal(readZipFile(t, archive, "xl/worksheets/sheet1.xml"), &activities); err != nil {
+		t.Fatalf("sheet1.xml inválido: %v", err)
+	}
+	if err := xml.Unmarshal(readZipFile(t, archive, "xl/worksheets/sheet2.xml"), &metadata); err != nil {
+		t.Fatalf("sheet2.xml inválido: %v", err)
+	}
+
+	// O texto é gravado como string, sem apóstrofo: o XLSX não o avalia
+	expected := [][]string{
+		{"DATA", "ID DA TAREFA", "ATIVIDADE", "STATUS", "URL", "HORAS"},
+		{
+			"06/01/2025", "PROJ-1", "=SOMA(1;2) & <b>", "Concluído",
+			"https://example.atlassian.net/browse/PROJ-1", "1",
+		},
+	}
+	rows := activities.texts()
+	if len(rows) != len(expected) {
+		t.Fatalf("Atividades: esperadas %d linhas, obtido %v", len(expected), rows)
+	}
+	for i := range expected {
+		if !slices.Equal(rows[i], expected[i]) {
+			t.Errorf("Atividades, linha %d: esperado %q, obtido %q", i+1, expected[i], rows[i])
+		}
+	}
+
+	header, hours := activities.Rows[0].Cells[0], activities.Rows[1].Cells[5]
+	if header.Ref != "A1" || header.Style != xlsxHeaderStyle {
+		t.Errorf("cabeçalho: esperado A1 com estilo %d, obtido %s com %d", xlsxHeaderStyle, header.Ref, header.Style)
+	}
+	if hours.Ref != "F2" || hours.Type != "" || hours.Style != xlsxNumberStyle {
+		t.Errorf("horas: esperada célula numérica F2, obtido %+v", hours)
+	}
+	for _, cell := range activities.Rows[1].Cells[:5] {
+		if cell.Type != "inlineStr" {
+			t.Errorf("célula %s: esperado texto, obtido tipo %q", cell.Ref, cell.Type)
+		}
+	}
+
+	values := map[string]string{}
+	for _, row := range metadata.texts() {
+		values[row[0]] = row[1]
+	}
+	expectedMetadata := map[string]string{
+		"RAZÃO SOCIAL":        "Empresa",
+		"CNPJ":                "00.000.000/0001-00",
+		"OBJETIVO DA SPRINT":  "Objetivo",
+		"TOTAL DE ATIVIDADES": "1",
+		"TOTAL DO MÊS":        "1,00",
+		"VALOR DA HORA":       "R$ 100,00",
+		"VALOR TOTAL":         "R$ 100,00",
+	}
+	for key, expected := range expectedMetadata {
+		if values[key] != expected {
+			t.Errorf("Metadados, %s: esperado %q, obtido %q", key, expected, values[key])
+		}
+	}
+
+	workbook := string(readZipFile(t, archive, "xl/workbook.xml"))
+	if !strings.Contains(workbook, `name="Atividades"`) || !strings.Contains(workbook, `name="Metadados"`) {
+		t.Errorf("planilhas ausentes no workbook: %s", workbook)
+	}
+}